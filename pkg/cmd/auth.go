@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage the logins to renku instances",
+	RunE:  runAuth,
+}
+
+func runAuth(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+func init() {
+	authCmd.AddCommand(authPruneCmd)
+	authCmd.AddCommand(authStatusCmd)
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/renkuapi"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var authPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove the saved logins of CI deployments which no longer exist",
+	Long: `Remove the saved logins of CI deployments whose namespace no longer exists in the cluster.
+Only instances following the CI hostname convention of a pull request deployment are considered.
+Logins saved by older versions of rdu are found for the deployments in the cluster, which are recorded to be pruned later,
+and for the last --closed-prs closed pull requests of each repository. Remove older ones with "rdu logout --url".`,
+	Run: authPrune,
+}
+
+func authPrune(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	dryRun := viper.GetBool("dry-run")
+	closedPRs := viper.GetInt("closed-prs")
+
+	clients, err := k8s.GetClientset()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	namespaces, err := k8s.ListNamespaces(ctx, clients)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	existing := map[string]bool{}
+	names := []string{}
+	for _, namespace := range namespaces.Items {
+		existing[namespace.Name] = true
+		names = append(names, namespace.Name)
+	}
+
+	// Logins saved by older versions of rdu are looked up for the deployments of recently closed pull requests too
+	instances, err := findInstances(append(names, listClosedDeploymentNamespaces(ctx, closedPRs)...))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	// Record the logins found, to prune them once their namespace is deleted
+	if !dryRun {
+		err = renkuapi.RecordInstances(instances)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning, could not record the saved logins: %s\n", err.Error())
+		}
+	}
+
+	pruned := 0
+	for _, instance := range instances {
+		namespace, found := ns.GetDeploymentNamespace(instance)
+		if !found || existing[namespace] {
+			continue
+		}
+		if _, pr := github.MatchDeploymentNamespace(namespace); pr == 0 {
+			continue
+		}
+
+		pruned++
+		if dryRun {
+			fmt.Printf("Would remove the login to %s (namespace '%s' does not exist)\n", instance, namespace)
+			continue
+		}
+		auth, err := renkuapi.NewRenkuApiAuth(instance)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = auth.Logout(ctx)
+		if err != nil {
+			fmt.Printf("Warning, could not remove every token of %s: %s\n", instance, err.Error())
+			continue
+		}
+		fmt.Printf("Removed the login to %s (namespace '%s' does not exist)\n", instance, namespace)
+	}
+
+	if pruned == 0 {
+		fmt.Println("Nothing to prune")
+	}
+}
+
+// listClosedDeploymentNamespaces returns the deployment namespaces of the last closed pull requests of each repository
+func listClosedDeploymentNamespaces(ctx context.Context, limit int) (namespaces []string) {
+	if limit <= 0 {
+		return nil
+	}
+	cli, err := github.NewGitHubClient(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning, could not list the closed pull requests: %s\n", err.Error())
+		return nil
+	}
+	for _, mapping := range github.GetNamespaceMappings() {
+		numbers, err := cli.ListClosedPullRequests(ctx, mapping.Repository, limit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning, could not list the closed pull requests of %s: %s\n", mapping.Repository, err.Error())
+			continue
+		}
+		for _, pr := range numbers {
+			namespace, err := github.DeriveK8sNamespace(mapping.Repository, pr)
+			if err == nil {
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+	return namespaces
+}
+
+func init() {
+	authPruneCmd.Flags().Bool("dry-run", false, "only print the logins which would be removed")
+	authPruneCmd.Flags().Int("closed-prs", 100, "how many closed pull requests of each repository are checked for logins saved by older versions of rdu")
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/renkuapi"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "List the renku instances with saved logins",
+	Long:  "List the renku instances with saved logins, with the user and the expiry of their tokens.\nLogins saved by older versions of rdu are only listed for the CI deployments which exist in the cluster.",
+	Run:   authStatus,
+}
+
+func authStatus(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	output := viper.GetString("output")
+
+	if output != "table" && output != "json" {
+		fmt.Printf("Invalid output format '%s', expected 'table' or 'json'\n", output)
+		os.Exit(1)
+	}
+
+	statuses, err := getInstanceStatuses(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if output == "json" {
+		out, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
+	if len(statuses) == 0 {
+		fmt.Println("No saved logins")
+		return
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "URL\tUSER\tACCESS TOKEN\tREFRESH TOKEN")
+	for _, status := range statuses {
+		access := formatTokenExpiry(status.HasAccessToken, status.AccessTokenExpiresAt, now)
+		refresh := formatTokenExpiry(status.HasRefreshToken, status.RefreshTokenExpiresAt, now)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", status.URL, status.User, access, refresh)
+	}
+	err = w.Flush()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func getInstanceStatuses(ctx context.Context) (statuses []renkuapi.InstanceStatus, err error) {
+	namespaces, err := listNamespaceNames(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning, could not list the namespaces of the cluster, only recorded logins are listed: %s\n", err.Error())
+	}
+
+	instances, err := findInstances(namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses = []renkuapi.InstanceStatus{}
+	for _, instance := range instances {
+		auth, err := renkuapi.NewRenkuApiAuth(instance)
+		if err != nil {
+			return nil, err
+		}
+		status, err := auth.Status()
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func listNamespaceNames(ctx context.Context) (namespaces []string, err error) {
+	clients, err := k8s.GetClientset()
+	if err != nil {
+		return nil, err
+	}
+	namespaceList, err := k8s.ListNamespaces(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+	namespaces = make([]string, 0, len(namespaceList.Items))
+	for _, namespace := range namespaceList.Items {
+		namespaces = append(namespaces, namespace.Name)
+	}
+	return namespaces, nil
+}
+
+// findInstances returns the instances with saved logins, including the logins saved by older versions of rdu
+// for the CI deployments of the given namespaces
+func findInstances(namespaces []string) (instances []string, err error) {
+	candidates := []string{}
+	for _, namespace := range namespaces {
+		if _, pr := github.MatchDeploymentNamespace(namespace); pr == 0 {
+			continue
+		}
+		deploymentURL, err := ns.GetDeploymentURL(namespace)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, deploymentURL.String())
+	}
+	return renkuapi.FindInstances(candidates)
+}
+
+func formatTokenExpiry(present bool, expiresAt *time.Time, now time.Time) string {
+	if !present {
+		return "none"
+	}
+	if expiresAt == nil {
+		return "no expiry"
+	}
+	if expiresAt.Before(now) {
+		return fmt.Sprintf("expired %s ago", duration.HumanDuration(now.Sub(*expiresAt)))
+	}
+	return fmt.Sprintf("expires in %s", duration.HumanDuration(expiresAt.Sub(now)))
+}
+
+func init() {
+	authStatusCmd.Flags().StringP("output", "o", "table", "output format (table or json)")
+}
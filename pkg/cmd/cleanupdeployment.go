@@ -6,14 +6,17 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
-	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/helm"
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
-	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 )
 
 var cleanupDeploymentCmd = &cobra.Command{
@@ -30,18 +33,14 @@ func cleanupDeployment(cmd *cobra.Command, args []string) {
 
 	namespace := viper.GetString("namespace")
 	deleteNamespace := viper.GetBool("delete-namespace")
+	dryRun := viper.GetBool("dry-run")
+	wait := viper.GetBool("wait")
+	timeout := viper.GetDuration("timeout")
 
-	if namespace == "" {
-		cli, err := github.NewGitHubCLI("")
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-		namespace, err = ns.FindCurrentNamespace(ctx, cli)
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
 
 	clients, err := k8s.GetClientset()
@@ -56,16 +55,18 @@ func cleanupDeployment(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Ask for confirmation
-	fmt.Printf("This command will perform the following actions in the namespace '%s':\n", namespace)
-	fmt.Println("  1. Delete all sessions")
-	fmt.Println("  2. Uninstall all helm releases")
-	fmt.Println("  3. Delete all jobs")
-	fmt.Println("  4. Delete all PVCs")
-	fmt.Println("  5. Forcibly delete all sessions")
-	if deleteNamespace {
-		fmt.Printf("  6. Delete the namespace '%s'\n", namespace)
+	plan, err := collectCleanupPlan(ctx, clients, client, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	printCleanupPlan(plan)
+	if dryRun {
+		return
 	}
+
+	fmt.Printf("This command will perform the following actions in the namespace '%s':\n", namespace)
+	printCleanupSteps(deleteNamespace)
 	proceed, err := askForConfirmation("Proceed?")
 	if err != nil {
 		fmt.Println(err)
@@ -75,25 +76,53 @@ func cleanupDeployment(cmd *cobra.Command, args []string) {
 		os.Exit(0)
 	}
 
-	// 1. Delete all sessions
-	fmt.Println("1. Delete all sessions")
-	err = k8s.DeleteAllSessions(ctx, client, namespace, k8s.DeleteAllSessionsOptions{})
+	err = cleanupNamespace(ctx, clients, client, namespace, deleteNamespace)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
+	if wait {
+		err = waitForCleanup(ctx, client, namespace, deleteNamespace, timeout)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+}
+
+func printCleanupSteps(deleteNamespace bool) {
+	fmt.Println("  1. Delete all sessions")
+	fmt.Println("  2. Uninstall all helm releases")
+	fmt.Println("  3. Delete all jobs")
+	fmt.Println("  4. Delete all PVCs")
+	fmt.Println("  5. Forcibly delete all sessions")
+	if deleteNamespace {
+		fmt.Println("  6. Delete the namespace")
+	}
+}
+
+// How long to wait for the resources of helm releases to be deleted
+const helmUninstallTimeout = 5 * time.Minute
+
+// cleanupNamespace runs the full cleanup sequence in the given namespace
+func cleanupNamespace(ctx context.Context, clients *kubernetes.Clientset, client *dynamic.DynamicClient, namespace string, deleteNamespace bool) error {
+	// 1. Delete all sessions
+	fmt.Println("1. Delete all sessions")
+	err := k8s.DeleteAllSessions(ctx, client, namespace, k8s.DeleteAllSessionsOptions{})
+	if err != nil {
+		return err
+	}
+
 	// 2. Uninstall all helm releases
 	fmt.Println("2. Uninstall all helm releases")
-	helm, err := helm.NewHelmCLI("")
+	releaseManager, err := newReleaseManager()
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return err
 	}
-	err = helm.UninstallAllReleases(ctx, namespace)
+	err = helm.UninstallAllReleases(ctx, releaseManager, namespace, helmUninstallTimeout)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return err
 	}
 
 	// 3. Delete all jobs
@@ -101,24 +130,21 @@ func cleanupDeployment(cmd *cobra.Command, args []string) {
 	propagation := metav1.DeletePropagationForeground
 	err = clients.BatchV1().Jobs(namespace).DeleteCollection(ctx, metav1.DeleteOptions{PropagationPolicy: &propagation}, metav1.ListOptions{})
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return err
 	}
 
 	// 4. Delete all PVCs
 	fmt.Println("4. Delete all PVCs")
 	err = clients.CoreV1().PersistentVolumeClaims(namespace).DeleteCollection(ctx, metav1.DeleteOptions{PropagationPolicy: &propagation}, metav1.ListOptions{})
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return err
 	}
 
 	// 5. Forcibly delete all sessions
 	fmt.Println("5. Forcibly delete all sessions")
 	err = k8s.ForciblyDeleteAllSessions(ctx, client, namespace, k8s.DeleteAllSessionsOptions{})
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return err
 	}
 
 	// 6. Delete the namespace
@@ -126,15 +152,152 @@ func cleanupDeployment(cmd *cobra.Command, args []string) {
 		fmt.Printf("6. Delete the namespace '%s'\n", namespace)
 		err = clients.CoreV1().Namespaces().Delete(ctx, namespace, metav1.DeleteOptions{PropagationPolicy: &propagation})
 		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+			return err
 		}
 	}
+
+	return nil
 }
 
 func init() {
 	cleanupDeploymentCmd.Flags().StringP("namespace", "n", "", "k8s namespace")
 	cleanupDeploymentCmd.Flags().Bool("delete-namespace", false, "if set, the namespace will be deleted")
+	cleanupDeploymentCmd.Flags().Bool("dry-run", false, "only print the resources which would be deleted")
+	cleanupDeploymentCmd.Flags().Bool("wait", false, "wait until all resources are deleted")
+	cleanupDeploymentCmd.Flags().Duration("timeout", 5*time.Minute, "how long to wait for resources to be deleted")
+}
+
+// waitForCleanup waits for the deleted resources to be gone and offers to
+// remove the finalizers of the resources which are stuck
+func waitForCleanup(ctx context.Context, client *dynamic.DynamicClient, namespace string, deleteNamespace bool, timeout time.Duration) error {
+	stuck, err := k8s.WaitForCleanup(ctx, client, namespace, deleteNamespace, timeout)
+	if err != nil {
+		return err
+	}
+	if len(stuck) == 0 {
+		fmt.Println("All resources have been deleted")
+		return nil
+	}
+
+	fmt.Printf("The following resources are still present after %s:\n", timeout.String())
+	for _, resource := range stuck {
+		fmt.Printf("  - %s (finalizers: %s)\n", resource.String(), strings.Join(resource.Finalizers, ", "))
+	}
+	proceed, err := askForConfirmation("Remove the finalizers of these resources?")
+	if err != nil {
+		return err
+	}
+	if !proceed {
+		return fmt.Errorf("some resources could not be deleted")
+	}
+
+	for _, resource := range stuck {
+		fmt.Printf("Removing finalizers from %s\n", resource.String())
+		err = k8s.RemoveFinalizers(ctx, client, resource)
+		if err != nil {
+			return err
+		}
+	}
+
+	stuck, err = k8s.WaitForCleanup(ctx, client, namespace, deleteNamespace, timeout)
+	if err != nil {
+		return err
+	}
+	if len(stuck) == 0 {
+		fmt.Println("All resources have been deleted")
+		return nil
+	}
+	fmt.Println("The following resources are still present after removing their finalizers:")
+	for _, resource := range stuck {
+		fmt.Printf("  - %s (finalizers: %s)\n", resource.String(), strings.Join(resource.Finalizers, ", "))
+	}
+	return fmt.Errorf("some resources could not be deleted")
+}
+
+// cleanupPlan holds the resources found in a namespace before cleaning it up
+type cleanupPlan struct {
+	namespace        string
+	amaltheaSessions []string
+	jupyterServers   []string
+	helmReleases     []helm.Release
+	jobs             []string
+	pvcs             []cleanupPlanPVC
+}
+
+type cleanupPlanPVC struct {
+	name string
+	size resource.Quantity
+}
+
+func collectCleanupPlan(ctx context.Context, clients *kubernetes.Clientset, client *dynamic.DynamicClient, namespace string) (plan cleanupPlan, err error) {
+	plan.namespace = namespace
+
+	plan.amaltheaSessions, err = k8s.ListAmaltheaSessions(ctx, client, namespace, nil)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return plan, err
+	}
+
+	plan.jupyterServers, err = k8s.ListJupyterServers(ctx, client, namespace, nil)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return plan, err
+	}
+
+	releaseManager, err := newReleaseManager()
+	if err != nil {
+		return plan, err
+	}
+	plan.helmReleases, err = releaseManager.ListReleases(ctx, namespace)
+	if err != nil {
+		return plan, err
+	}
+
+	jobList, err := k8s.ListJobs(ctx, clients, namespace)
+	if err != nil {
+		return plan, err
+	}
+	for _, job := range jobList.Items {
+		plan.jobs = append(plan.jobs, job.Name)
+	}
+
+	pvcList, err := k8s.ListPersistentVolumeClaims(ctx, clients, namespace)
+	if err != nil {
+		return plan, err
+	}
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		plan.pvcs = append(plan.pvcs, cleanupPlanPVC{name: pvc.Name, size: k8s.GetPersistentVolumeClaimSize(pvc)})
+	}
+
+	return plan, nil
+}
+
+func printCleanupPlan(plan cleanupPlan) {
+	fmt.Printf("Resources found in the namespace '%s':\n", plan.namespace)
+
+	fmt.Printf("  AmaltheaSessions (%d):\n", len(plan.amaltheaSessions))
+	for _, name := range plan.amaltheaSessions {
+		fmt.Printf("    - %s\n", name)
+	}
+	fmt.Printf("  JupyterServers (%d):\n", len(plan.jupyterServers))
+	for _, name := range plan.jupyterServers {
+		fmt.Printf("    - %s\n", name)
+	}
+	fmt.Printf("  Helm releases (%d):\n", len(plan.helmReleases))
+	for _, release := range plan.helmReleases {
+		fmt.Printf("    - %s (%s-%s, %s)\n", release.Name, release.Chart, release.ChartVersion, release.Status)
+	}
+	fmt.Printf("  Jobs (%d):\n", len(plan.jobs))
+	for _, name := range plan.jobs {
+		fmt.Printf("    - %s\n", name)
+	}
+	totalSize := resource.Quantity{}
+	for _, pvc := range plan.pvcs {
+		totalSize.Add(pvc.size)
+	}
+	fmt.Printf("  PVCs (%d, total %s):\n", len(plan.pvcs), totalSize.String())
+	for _, pvc := range plan.pvcs {
+		fmt.Printf("    - %s (%s)\n", pvc.name, pvc.size.String())
+	}
 }
 
 func askForConfirmation(question string) (response bool, err error) {
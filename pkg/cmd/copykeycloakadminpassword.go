@@ -5,9 +5,7 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
-	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"golang.design/x/clipboard"
@@ -30,17 +28,10 @@ func runCopyKeycloakAdminPassword(cmd *cobra.Command, args []string) {
 	secretName := viper.GetString("secret-name")
 	secretKey := viper.GetString("secret-key")
 
-	if namespace == "" {
-		cli, err := github.NewGitHubCLI("")
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-		namespace, err = ns.FindCurrentNamespace(ctx, cli)
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
 
 	clients, err := k8s.GetClientset()
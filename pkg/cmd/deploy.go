@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/helm"
+	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var deployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Install or upgrade a renku chart into a namespace",
+	Long:  fmt.Sprintf("Install or upgrade a renku chart into a namespace and wait for it to be ready.\nComponents for --image: %s", strings.Join(helm.GetComponents(), ", ")),
+	Run:   deploy,
+}
+
+func deploy(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	namespace := viper.GetString("namespace")
+	repository := viper.GetString("repo")
+	pr := viper.GetInt("pr")
+	chart := helm.ChartRef{
+		Name:    viper.GetString("chart"),
+		RepoURL: viper.GetString("chart-repo"),
+		Version: viper.GetString("version"),
+	}
+	release := viper.GetString("release")
+	valueFiles := viper.GetStringSlice("values")
+	setValues := viper.GetStringSlice("set")
+	images := viper.GetStringSlice("image")
+	timeout := viper.GetDuration("timeout")
+
+	// Local charts do not come from the chart repository
+	if info, err := os.Stat(chart.Name); err == nil && info.IsDir() {
+		chart.RepoURL = ""
+	}
+
+	var err error
+	if namespace == "" && pr > 0 {
+		if repository == "" {
+			fmt.Println("--repo is required with --pr")
+			os.Exit(1)
+		}
+		namespace, err = github.DeriveK8sNamespace(repository, pr)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Namespace: %s (from %s#%d)\n", namespace, repository, pr)
+	} else {
+		namespace, err = resolveNamespace(ctx, namespace)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	deploymentURL, err := ns.GetDeploymentURL(namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	values, err := helm.MergeValues(valueFiles, setValues)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	for _, image := range images {
+		component, ref, found := strings.Cut(image, "=")
+		if !found {
+			fmt.Printf("Invalid image override '%s', expected component=image:tag\n", image)
+			os.Exit(1)
+		}
+		err = helm.SetComponentImage(values, component, ref)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+	setHostnameValues(values, deploymentURL.Hostname())
+
+	fmt.Printf("Deploying chart '%s' as release '%s' in namespace '%s'\n", chart.Name, release, namespace)
+	rm, err := newReleaseManager()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	result, err := rm.InstallOrUpgrade(ctx, namespace, release, chart, values, timeout)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Release '%s' revision %d: %s (chart %s-%s)\n", result.Name, result.Revision, result.Status, result.Chart, result.ChartVersion)
+	fmt.Printf("Deployment URL: %s\n", deploymentURL.String())
+}
+
+// setHostnameValues sets the hostname of the deployment like CI does, unless the values already set it
+func setHostnameValues(values map[string]any, host string) {
+	helm.SetDefaultValue(values, []string{"global", "renku", "domain"}, host)
+	helm.SetDefaultValue(values, []string{"ingress", "hosts"}, []any{host})
+	helm.SetDefaultValue(values, []string{"ingress", "tls"}, []any{
+		map[string]any{"hosts": []any{host}, "secretName": fmt.Sprintf("%s-tls", host)},
+	})
+}
+
+// The repository of the renku chart
+const defaultChartRepository string = "https://swissdatasciencecenter.github.io/helm-charts"
+
+func init() {
+	deployCmd.Flags().StringP("namespace", "n", "", "k8s namespace")
+	deployCmd.Flags().String("repo", "", "repository of the pull request to deploy, e.g. SwissDataScienceCenter/renku-data-services")
+	deployCmd.Flags().Int("pr", 0, "pull request to deploy, used with --repo to derive the namespace")
+	deployCmd.Flags().String("chart", "renku", "chart name, or a path to a local chart")
+	deployCmd.Flags().String("chart-repo", defaultChartRepository, "chart repository URL")
+	deployCmd.Flags().String("version", "", "chart version (default: latest)")
+	deployCmd.Flags().String("release", "renku", "helm release name")
+	deployCmd.Flags().StringSliceP("values", "f", []string{}, "values files")
+	deployCmd.Flags().StringSlice("set", []string{}, "values to set, e.g. key=value")
+	deployCmd.Flags().StringSlice("image", []string{}, "image overrides, e.g. data-service=renku/renku-data-service:0.1.0")
+	deployCmd.Flags().Duration("timeout", 15*time.Minute, "time to wait for the deployment to be ready")
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/helm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [namespace-a] [namespace-b]",
+	Short: "Compare the helm values and chart versions of two deployments",
+	Long: `Compare the helm values and chart versions of two deployments.
+With one namespace, the current deployment is compared to it.
+Without any namespace, the current deployment is compared to the --reference namespace.`,
+	Args: cobra.MaximumNArgs(2),
+	Run:  diff,
+}
+
+// deploymentValues holds what is deployed by a release in a namespace
+type deploymentValues struct {
+	Namespace    string            `json:"namespace"`
+	ChartVersion string            `json:"chartVersion"`
+	AppVersion   string            `json:"appVersion"`
+	Images       map[string]string `json:"images"`
+	values       map[string]any
+}
+
+// deploymentDiff is the difference between two deployments
+type deploymentDiff struct {
+	Release string           `json:"release"`
+	A       deploymentValues `json:"a"`
+	B       deploymentValues `json:"b"`
+	Values  []helm.ValueDiff `json:"values"`
+}
+
+// The namespace of the reference deployment
+const defaultDiffReference string = "renku"
+
+// Overrides the reference namespace of diff
+const diffReferenceEnv string = "RDU_DIFF_REFERENCE"
+
+func diff(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	release := viper.GetString("release")
+	reference := viper.GetString("reference")
+	if env := os.Getenv(diffReferenceEnv); env != "" && !cmd.Flags().Changed("reference") {
+		reference = env
+	}
+	allValues := viper.GetBool("all")
+	output := viper.GetString("output")
+
+	if output != "table" && output != "json" {
+		fmt.Printf("Invalid output format '%s', expected 'table' or 'json'\n", output)
+		os.Exit(1)
+	}
+
+	var namespaceA, namespaceB string
+	switch len(args) {
+	case 2:
+		namespaceA, namespaceB = args[0], args[1]
+	case 1:
+		namespaceB = args[0]
+	default:
+		namespaceB = reference
+	}
+	if namespaceB == "" {
+		fmt.Println("A namespace to compare to is required, pass it as an argument or with --reference")
+		os.Exit(1)
+	}
+
+	namespaceA, err := resolveNamespace(ctx, namespaceA)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	rm, err := newReleaseManager()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	a, err := getDeploymentValues(ctx, rm, namespaceA, release, allValues)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	b, err := getDeploymentValues(ctx, rm, namespaceB, release, allValues)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	result := deploymentDiff{
+		Release: release,
+		A:       a,
+		B:       b,
+		Values:  helm.DiffValues(a.values, b.values),
+	}
+
+	if output == "json" {
+		if result.Values == nil {
+			result.Values = []helm.ValueDiff{}
+		}
+		out, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
+	err = printDeploymentDiff(result)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func getDeploymentValues(ctx context.Context, rm helm.ReleaseManager, namespace string, release string, allValues bool) (result deploymentValues, err error) {
+	result = deploymentValues{Namespace: namespace, Images: map[string]string{}}
+
+	releases, err := rm.ListReleases(ctx, namespace)
+	if err != nil {
+		return result, err
+	}
+	found := false
+	for _, r := range releases {
+		if r.Name == release {
+			result.ChartVersion = r.ChartVersion
+			result.AppVersion = r.AppVersion
+			found = true
+			break
+		}
+	}
+	if !found {
+		return result, fmt.Errorf("release '%s' not found in namespace '%s'", release, namespace)
+	}
+
+	// Images are mostly set by the chart defaults, so they are read from the computed values
+	computed, err := rm.GetValues(ctx, namespace, release, true)
+	if err != nil {
+		return result, err
+	}
+	for _, component := range helm.GetComponents() {
+		image, found := helm.GetComponentImage(computed, component)
+		if found {
+			result.Images[component] = image
+		}
+	}
+
+	result.values = computed
+	if !allValues {
+		result.values, err = rm.GetValues(ctx, namespace, release, false)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func printDeploymentDiff(result deploymentDiff) error {
+	a, b := result.A, result.B
+	fmt.Printf("Comparing release '%s': %s (a) vs %s (b)\n\n", result.Release, a.Namespace, b.Namespace)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tA\tB\t")
+	fmt.Fprintf(w, "chart version\t%s\t%s\t%s\n", a.ChartVersion, b.ChartVersion, diffMarker(a.ChartVersion, b.ChartVersion))
+	fmt.Fprintf(w, "app version\t%s\t%s\t%s\n", a.AppVersion, b.AppVersion, diffMarker(a.AppVersion, b.AppVersion))
+	for _, component := range helm.GetComponents() {
+		imageA, imageB := a.Images[component], b.Images[component]
+		if imageA == "" && imageB == "" {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", component, orNone(imageA), orNone(imageB), diffMarker(imageA, imageB))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(result.Values) == 0 {
+		fmt.Println("The values are identical")
+		return nil
+	}
+	fmt.Printf("%d values differ:\n", len(result.Values))
+	for _, valueDiff := range result.Values {
+		switch {
+		case valueDiff.Old == nil:
+			fmt.Printf("+ %s: %s\n", valueDiff.Path, formatValue(valueDiff.New))
+		case valueDiff.New == nil:
+			fmt.Printf("- %s: %s\n", valueDiff.Path, formatValue(valueDiff.Old))
+		default:
+			fmt.Printf("~ %s: %s -> %s\n", valueDiff.Path, formatValue(valueDiff.Old), formatValue(valueDiff.New))
+		}
+	}
+	return nil
+}
+
+func diffMarker(a string, b string) string {
+	if a != b {
+		return "*"
+	}
+	return ""
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func formatValue(value any) string {
+	out, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(out)
+}
+
+func init() {
+	diffCmd.Flags().String("release", "renku", "helm release name")
+	diffCmd.Flags().String("reference", defaultDiffReference, fmt.Sprintf("namespace to compare to when no namespace is given, can also be set with %s", diffReferenceEnv))
+	diffCmd.Flags().Bool("all", false, "compare all the computed values instead of the user-supplied values")
+	diffCmd.Flags().StringP("output", "o", "table", "output format (table or json)")
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/helm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var linkedPRsCmd = &cobra.Command{
+	Use:   "linked-prs",
+	Short: "List the pull requests linked to a deployment",
+	Long:  "List the pull requests linked to a deployment.\nLinked pull requests are read from the description of the pull request of the deployment,\nand checked against the images and chart deployed in the namespace.",
+	Run:   linkedPRs,
+}
+
+// Maps repositories to the renku components built from them
+var repositoryComponents = map[string][]string{
+	"SwissDataScienceCenter/renku-data-services": {"data-service", "data-tasks", "k8s-watcher", "secrets-storage"},
+	"SwissDataScienceCenter/renku-gateway":       {"gateway"},
+	"SwissDataScienceCenter/renku-notebooks":     {"notebooks"},
+	"SwissDataScienceCenter/renku-search":        {"search-api", "search-provision"},
+	"SwissDataScienceCenter/renku-ui":            {"ui", "ui-server"},
+}
+
+// The repository of the renku chart
+const renkuChartRepository string = "SwissDataScienceCenter/renku"
+
+// linkedPullRequest describes a pull request linked to a deployment
+type linkedPullRequest struct {
+	Repository string `json:"repository"`
+	PR         int    `json:"pr"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	HeadSHA    string `json:"headSha"`
+	Checks     string `json:"checks"`
+	// yes, no or unknown
+	Deployed         string   `json:"deployed"`
+	DeployedVersions []string `json:"deployedVersions,omitempty"`
+	Error            string   `json:"error,omitempty"`
+}
+
+func linkedPRs(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	namespace := viper.GetString("namespace")
+	release := viper.GetString("release")
+	output := viper.GetString("output")
+
+	if output != "table" && output != "json" {
+		fmt.Printf("Invalid output format '%s', expected 'table' or 'json'\n", output)
+		os.Exit(1)
+	}
+
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	repository, pr := github.MatchDeploymentNamespace(namespace)
+	if pr == 0 {
+		fmt.Printf("Namespace '%s' does not belong to a pull request\n", namespace)
+		os.Exit(1)
+	}
+
+	cli, err := github.NewGitHubClient(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	pullRequest, err := cli.GetPullRequest(ctx, repository, pr)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	versions, err := getDeployedVersions(ctx, namespace, release)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning, could not read the deployed versions: %s\n", err.Error())
+	}
+
+	refs := []github.PullRequestRef{{Repository: repository, Number: pr}}
+	for _, ref := range github.ParseLinkedPullRequests(pullRequest.Body) {
+		if ref != refs[0] {
+			refs = append(refs, ref)
+		}
+	}
+
+	linked := make([]linkedPullRequest, len(refs))
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			linked[i] = inspectLinkedPullRequest(ctx, cli, ref, versions[ref.Repository])
+		}()
+	}
+	wg.Wait()
+
+	err = printLinkedPullRequests(linked, output)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if output != "table" {
+		return
+	}
+	for _, pull := range linked {
+		if pull.Deployed == "no" {
+			fmt.Printf("%s#%d has new commits which are not deployed yet (head: %s, deployed: %s)\n", pull.Repository, pull.PR, shortSHA(pull.HeadSHA), strings.Join(pull.DeployedVersions, ", "))
+		}
+	}
+}
+
+// getDeployedVersions returns the image tags and chart versions deployed in a namespace, by repository
+func getDeployedVersions(ctx context.Context, namespace string, release string) (versions map[string][]string, err error) {
+	versions = map[string][]string{}
+
+	releaseManager, err := newReleaseManager()
+	if err != nil {
+		return versions, err
+	}
+	releases, err := releaseManager.ListReleases(ctx, namespace)
+	if err != nil {
+		return versions, err
+	}
+	for _, r := range releases {
+		if r.Name == release {
+			versions[renkuChartRepository] = append(versions[renkuChartRepository], r.ChartVersion)
+		}
+	}
+
+	values, err := releaseManager.GetValues(ctx, namespace, release, true)
+	if err != nil {
+		return versions, err
+	}
+	for repository, components := range repositoryComponents {
+		for _, component := range components {
+			image, found := helm.GetComponentImage(values, component)
+			if found {
+				versions[repository] = append(versions[repository], image)
+			}
+		}
+	}
+	return versions, nil
+}
+
+func inspectLinkedPullRequest(ctx context.Context, cli github.GitHubClient, ref github.PullRequestRef, versions []string) (linked linkedPullRequest) {
+	linked = linkedPullRequest{Repository: ref.Repository, PR: ref.Number, Deployed: "unknown", DeployedVersions: versions}
+
+	pullRequest, err := cli.GetPullRequest(ctx, ref.Repository, ref.Number)
+	if err != nil {
+		linked.Error = err.Error()
+		return linked
+	}
+	linked.Title = pullRequest.Title
+	linked.State = pullRequest.State
+	linked.HeadSHA = pullRequest.HeadSHA
+
+	if pullRequest.HeadSHA == "" {
+		return linked
+	}
+
+	linked.Checks, err = cli.GetCheckStatus(ctx, ref.Repository, pullRequest.HeadSHA)
+	if err != nil {
+		linked.Error = err.Error()
+	}
+
+	if len(versions) > 0 {
+		linked.Deployed = "no"
+		for _, version := range versions {
+			if strings.Contains(version, shortSHA(pullRequest.HeadSHA)) {
+				linked.Deployed = "yes"
+				break
+			}
+		}
+	}
+	return linked
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func printLinkedPullRequests(linked []linkedPullRequest, output string) error {
+	if output == "json" {
+		out, err := json.MarshalIndent(linked, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPOSITORY\tPR\tSTATE\tHEAD\tCHECKS\tDEPLOYED\tTITLE")
+	for _, pull := range linked {
+		title := pull.Title
+		if pull.Error != "" {
+			title = fmt.Sprintf("error: %s", pull.Error)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n", pull.Repository, pull.PR, pull.State, shortSHA(pull.HeadSHA), pull.Checks, pull.Deployed, title)
+	}
+	return w.Flush()
+}
+
+func init() {
+	linkedPRsCmd.Flags().StringP("namespace", "n", "", "k8s namespace")
+	linkedPRsCmd.Flags().String("release", "renku", "helm release name")
+	linkedPRsCmd.Flags().StringP("output", "o", "table", "output format (table or json)")
+}
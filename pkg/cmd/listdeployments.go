@@ -2,12 +2,22 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
 
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/helm"
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
 )
 
 var listDeploymentsCmd = &cobra.Command{
@@ -17,42 +27,206 @@ var listDeploymentsCmd = &cobra.Command{
 	Run:     listDeployments,
 }
 
+// How many deployments are inspected at the same time
+const listDeploymentsConcurrency = 8
+
+// deploymentInfo describes a deployment namespace and its pull request
+type deploymentInfo struct {
+	Namespace    string    `json:"namespace"`
+	Repository   string    `json:"repository,omitempty"`
+	PR           int       `json:"pr,omitempty"`
+	Title        string    `json:"title,omitempty"`
+	Author       string    `json:"author,omitempty"`
+	State        string    `json:"state,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	ChartVersion string    `json:"chartVersion,omitempty"`
+	Sessions     int       `json:"sessions"`
+}
+
 func listDeployments(cmd *cobra.Command, args []string) {
 	ctx := cmd.Context()
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	cli, err := github.NewGitHubCLI("")
+	output := viper.GetString("output")
+	state := strings.ToUpper(viper.GetString("state"))
+	mine := viper.GetBool("mine")
+	prsOnly := viper.GetBool("prs-only")
+	cacheTTL := viper.GetDuration("cache-ttl")
+
+	if output != "table" && output != "json" && output != "yaml" {
+		fmt.Printf("Invalid output format '%s', expected 'table', 'json' or 'yaml'\n", output)
+		os.Exit(1)
+	}
+	if state != "" && state != "OPEN" && state != "CLOSED" && state != "MERGED" {
+		fmt.Printf("Invalid state '%s', expected 'open', 'closed' or 'merged'\n", viper.GetString("state"))
+		os.Exit(1)
+	}
+
+	cli, err := github.NewGitHubClient(ctx)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
+	user := ""
+	if mine {
+		user, err = cli.GetCurrentUser(ctx)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
 	clients, err := k8s.GetClientset()
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
+	client, err := k8s.GetDynamicClient()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	rm, err := newReleaseManager()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
 	namespaceList, err := k8s.ListNamespaces(ctx, clients)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	for i := range namespaceList.Items {
-		ns := namespaceList.Items[i]
-		repo, pr := github.MatchDeploymentNamespace(ns.Name)
-		if repo != "" {
-			state, err := cli.GetPullRequestState(ctx, repo, pr)
-			if err != nil {
-				state = "UNKNOWN"
-				fmt.Println(err)
-			}
-			fmt.Printf("%s\t%s\t%d\t%s\n", ns.Name, repo, pr, state)
+	deployments := []deploymentInfo{}
+	for _, namespace := range namespaceList.Items {
+		repo, pr := github.MatchDeploymentNamespace(namespace.Name)
+		if repo == "" && prsOnly {
+			continue
+		}
+		deployments = append(deployments, deploymentInfo{
+			Namespace:  namespace.Name,
+			Repository: repo,
+			PR:         pr,
+			CreatedAt:  namespace.CreationTimestamp.Time,
+		})
+	}
+
+	cachePath := ""
+	if cacheTTL > 0 {
+		cachePath = getCachePath("pull-requests.json")
+	}
+	cache := github.LoadPullRequestCache(cachePath, cacheTTL)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, listDeploymentsConcurrency)
+	for i := range deployments {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			inspectDeployment(ctx, cli, cache, rm, client, &deployments[i])
+		}()
+	}
+	wg.Wait()
+
+	err = cache.Save()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not save the pull request cache: %s\n", err.Error())
+	}
+
+	filtered := []deploymentInfo{}
+	for _, deployment := range deployments {
+		if state != "" && deployment.State != state {
+			continue
+		}
+		if mine && deployment.Author != user {
+			continue
+		}
+		filtered = append(filtered, deployment)
+	}
+
+	err = printDeployments(filtered, output)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// inspectDeployment fills in the pull request, chart version and session count of a deployment.
+// Failed lookups are reported as warnings and leave the fields empty.
+func inspectDeployment(ctx context.Context, cli github.GitHubClient, cache *github.PullRequestCache, rm helm.ReleaseManager, client *dynamic.DynamicClient, deployment *deploymentInfo) {
+	if deployment.Repository != "" {
+		pullRequest, err := cache.GetPullRequest(ctx, cli, deployment.Repository, deployment.PR)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not get %s#%d: %s\n", deployment.Repository, deployment.PR, err.Error())
+			deployment.State = "UNKNOWN"
 		} else {
-			fmt.Printf("%s\n", ns.Name)
+			deployment.Title = pullRequest.Title
+			deployment.Author = pullRequest.Author
+			deployment.State = pullRequest.State
+		}
+	}
+
+	releases, err := rm.ListReleases(ctx, deployment.Namespace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not list the helm releases of '%s': %s\n", deployment.Namespace, err.Error())
+	}
+	for _, release := range releases {
+		if release.Name == "renku" || deployment.ChartVersion == "" {
+			deployment.ChartVersion = release.ChartVersion
 		}
 	}
+
+	sessions, err := k8s.ListSessions(ctx, client, deployment.Namespace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not list the sessions of '%s': %s\n", deployment.Namespace, err.Error())
+	}
+	deployment.Sessions = len(sessions)
+}
+
+func printDeployments(deployments []deploymentInfo, output string) error {
+	switch output {
+	case "json":
+		out, err := json.MarshalIndent(deployments, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	case "yaml":
+		out, err := yaml.Marshal(deployments)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(out))
+		return nil
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tREPOSITORY\tPR\tSTATE\tAUTHOR\tAGE\tCHART\tSESSIONS\tTITLE")
+	for _, deployment := range deployments {
+		pr := ""
+		if deployment.PR > 0 {
+			pr = fmt.Sprintf("%d", deployment.PR)
+		}
+		age := duration.HumanDuration(now.Sub(deployment.CreatedAt))
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n", deployment.Namespace, deployment.Repository, pr, deployment.State, deployment.Author, age, deployment.ChartVersion, deployment.Sessions, deployment.Title)
+	}
+	return w.Flush()
+}
+
+func init() {
+	listDeploymentsCmd.Flags().StringP("output", "o", "table", "output format (table, json or yaml)")
+	listDeploymentsCmd.Flags().String("state", "", "only list deployments whose pull request is in this state (open, closed or merged)")
+	listDeploymentsCmd.Flags().Bool("mine", false, "only list deployments of my pull requests")
+	listDeploymentsCmd.Flags().Bool("prs-only", false, "only list namespaces which belong to a pull request")
+	listDeploymentsCmd.Flags().Duration("cache-ttl", 5*time.Minute, "how long pull requests are cached, 0 to disable the cache")
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
 	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/renkuapi"
 	"github.com/spf13/cobra"
@@ -38,20 +37,14 @@ func logout(cmd *cobra.Command, args []string) {
 	}
 
 	if url == "" {
-		if namespace == "" {
-			cli, err := github.NewGitHubCLI("")
-			if err != nil {
-				fmt.Println(err)
-				os.Exit(1)
-			}
-			namespace, err = ns.FindCurrentNamespace(ctx, cli)
-			if err != nil {
-				fmt.Println(err)
-				os.Exit(1)
-			}
+		var err error
+		namespace, err = resolveNamespace(ctx, namespace)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
 
-		deploymentURL, err := ns.GetDeploymentURL(namespace)
+		deploymentURL, err := ns.ResolveDeploymentURL(ctx, namespace)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
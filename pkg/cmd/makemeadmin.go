@@ -6,7 +6,6 @@ import (
 	"os"
 
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/git"
-	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/keycloak"
 	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
@@ -47,17 +46,10 @@ func makeMeAdmin(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	if namespace == "" {
-		cli, err := github.NewGitHubCLI("")
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-		namespace, err = ns.FindCurrentNamespace(ctx, cli)
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
 
 	clients, err := k8s.GetClientset()
@@ -84,7 +76,7 @@ func makeMeAdmin(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	deploymentURL, err := ns.GetDeploymentURL(namespace)
+	deploymentURL, err := ns.ResolveDeploymentURL(ctx, namespace)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
-	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
 	"github.com/spf13/cobra"
 )
 
@@ -23,12 +21,7 @@ func namespaceFn(cmd *cobra.Command, args []string) {
 		ctx = context.Background()
 	}
 
-	cli, err := github.NewGitHubCLI("")
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-	namespace, err := ns.FindCurrentNamespace(ctx, cli)
+	namespace, err := resolveNamespace(ctx, "")
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
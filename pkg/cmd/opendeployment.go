@@ -8,7 +8,6 @@ import (
 	"runtime"
 
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/executils"
-	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
 	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -28,20 +27,13 @@ func openDeployment(cmd *cobra.Command, args []string) {
 
 	namespace := viper.GetString("namespace")
 
-	if namespace == "" {
-		cli, err := github.NewGitHubCLI("")
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-		namespace, err = ns.FindCurrentNamespace(ctx, cli)
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
 
-	openURL, err := ns.GetDeploymentURL(namespace)
+	openURL, err := ns.ResolveDeploymentURL(ctx, namespace)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/client-go/kubernetes"
+)
+
+var portForwardCmd = &cobra.Command{
+	Use:     "port-forward [component[:local-port]...]",
+	Aliases: []string{"pf"},
+	Short:   "Forward local ports to renku components",
+	Long:    fmt.Sprintf("Forward local ports to renku components.\nKnown components: %s (default: all the deployed ones)", strings.Join(getRenkuComponentNames(), ", ")),
+	Run:     portForward,
+}
+
+// renkuComponent describes how to reach a renku component
+type renkuComponent struct {
+	// candidate service names, in order of preference
+	services []string
+	// the local port used by default
+	localPort int32
+	// the scheme printed in the local URL
+	scheme string
+}
+
+var renkuComponents = map[string]renkuComponent{
+	"data-service": {services: []string{"renku-data-service"}, localPort: 18000, scheme: "http"},
+	"gateway":      {services: []string{"renku-gateway"}, localPort: 18001, scheme: "http"},
+	"keycloak":     {services: []string{"renku-keycloak", "keycloak"}, localPort: 18002, scheme: "http"},
+	"postgres":     {services: []string{"renku-postgresql", "postgresql"}, localPort: 15432, scheme: "postgresql"},
+	"search":       {services: []string{"renku-search-api", "search-api"}, localPort: 18003, scheme: "http"},
+}
+
+func getRenkuComponentNames() []string {
+	names := make([]string, 0, len(renkuComponents))
+	for name := range renkuComponents {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func portForward(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	namespace := viper.GetString("namespace")
+	address := viper.GetString("address")
+	verbose := viper.GetBool("verbose")
+
+	// Components which are not deployed are skipped when forwarding all of them
+	all := len(args) == 0
+	if all {
+		args = getRenkuComponentNames()
+	}
+
+	localPorts := map[string]int32{}
+	names := []string{}
+	for _, arg := range args {
+		name, portStr, found := strings.Cut(arg, ":")
+		component, known := renkuComponents[name]
+		if !known {
+			fmt.Printf("Unknown component '%s', expected one of: %s\n", name, strings.Join(getRenkuComponentNames(), ", "))
+			os.Exit(1)
+		}
+		localPorts[name] = component.localPort
+		if found {
+			port, err := strconv.ParseInt(portStr, 10, 32)
+			if err != nil {
+				fmt.Printf("Invalid local port '%s' for component '%s'\n", portStr, name)
+				os.Exit(1)
+			}
+			localPorts[name] = int32(port)
+		}
+		names = append(names, name)
+	}
+
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	clients, err := k8s.GetClientset()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	targets := map[string]k8s.ServiceTarget{}
+	found := []string{}
+	for _, name := range names {
+		target, err := findComponentTarget(ctx, clients, namespace, name)
+		if err != nil && all {
+			fmt.Fprintf(os.Stderr, "Warning, skipping component '%s': %s\n", name, err.Error())
+			continue
+		} else if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		targets[name] = target
+		found = append(found, name)
+	}
+	names = found
+	if len(names) == 0 {
+		fmt.Printf("No renku component found in namespace '%s'\n", namespace)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	var out io.Writer = io.Discard
+	if verbose {
+		out = os.Stdout
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(names))
+	for _, name := range names {
+		target := targets[name]
+		ready := make(chan struct{})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := k8s.PortForward(ctx, clients, namespace, target.Pod, address, localPorts[name], target.PodPort, ready, out)
+			if err != nil {
+				errs <- fmt.Errorf("%s: %w", name, err)
+				stop()
+			}
+		}()
+		select {
+		case <-ready:
+			fmt.Printf("%-14s %s://%s:%d -> %s/%s:%d\n", name, renkuComponents[name].scheme, address, localPorts[name], target.Service, target.Pod, target.PodPort)
+		case <-ctx.Done():
+		}
+	}
+
+	if ctx.Err() == nil {
+		fmt.Println("Press Ctrl+C to stop forwarding")
+	}
+	wg.Wait()
+	close(errs)
+
+	failed := false
+	for err := range errs {
+		fmt.Println(err)
+		failed = true
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func findComponentTarget(ctx context.Context, clients *kubernetes.Clientset, namespace string, name string) (target k8s.ServiceTarget, err error) {
+	service, err := k8s.FindService(ctx, clients, namespace, renkuComponents[name].services, name)
+	if err != nil {
+		return target, err
+	}
+	return k8s.FindServiceTarget(ctx, clients, service)
+}
+
+func init() {
+	portForwardCmd.Flags().StringP("namespace", "n", "", "k8s namespace")
+	portForwardCmd.Flags().String("address", "localhost", "local address to listen on")
+	portForwardCmd.Flags().BoolP("verbose", "v", false, "print the port forwarding logs")
+}
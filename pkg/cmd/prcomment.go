@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/helm"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/renkuapi/session"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var prCommentCmd = &cobra.Command{
+	Use:   "pr-comment",
+	Short: "Post the details of a deployment as a comment on the current pull request",
+	Long:  "Post the details of a deployment as a comment on the current pull request.\nThe deployment is the one of the pull request, unless --namespace is given.\nThe comment is updated in place when the command runs again.",
+	Run:   prComment,
+}
+
+// Identifies the comment posted by pr-comment
+const prCommentMarker string = "<!-- rdu-deployment-comment -->"
+
+// deploymentComment holds the details posted on a pull request
+type deploymentComment struct {
+	namespace    string
+	url          string
+	healthy      bool
+	images       map[string]string
+	globalImages string
+}
+
+func prComment(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	namespace := viper.GetString("namespace")
+	release := viper.GetString("release")
+	dryRun := viper.GetBool("dry-run")
+	force := viper.GetBool("force")
+
+	cli, err := github.NewGitHubClient(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	repository, err := cli.GetCurrentRepository(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	pr, err := cli.GetCurrentPullRequest(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	expected, err := github.DeriveK8sNamespace(repository, pr)
+	if namespace == "" {
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		namespace = expected
+		fmt.Fprintf(os.Stderr, "Namespace: %s (from %s#%d)\n", namespace, repository, pr)
+	} else if namespace != expected && !force && !dryRun {
+		fmt.Printf("Namespace '%s' is not the deployment of %s#%d, pass --force to comment anyway\n", namespace, repository, pr)
+		os.Exit(1)
+	}
+
+	comment, err := collectDeploymentComment(ctx, namespace, release)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	body := comment.render()
+
+	if dryRun {
+		fmt.Println(body)
+		return
+	}
+
+	created, err := github.UpsertStickyComment(ctx, cli, repository, pr, prCommentMarker, body)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if created {
+		fmt.Printf("Commented on %s#%d\n", repository, pr)
+	} else {
+		fmt.Printf("Updated the comment on %s#%d\n", repository, pr)
+	}
+}
+
+func collectDeploymentComment(ctx context.Context, namespace string, release string) (c deploymentComment, err error) {
+	c.namespace = namespace
+
+	clients, err := k8s.GetClientset()
+	if err != nil {
+		return c, err
+	}
+	status, err := collectDeploymentStatus(ctx, clients, namespace)
+	if err != nil {
+		return c, err
+	}
+	c.url = status.url
+	c.healthy = status.isHealthy()
+
+	releaseManager, err := newReleaseManager()
+	if err != nil {
+		return c, err
+	}
+	values, err := releaseManager.GetValues(ctx, namespace, release, true)
+	if err != nil {
+		return c, err
+	}
+	c.images = map[string]string{}
+	for _, component := range helm.GetComponents() {
+		image, found := helm.GetComponentImage(values, component)
+		if found {
+			c.images[component] = image
+		}
+	}
+
+	c.globalImages, err = getGlobalImagesRelease(ctx, c.url)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning, could not list the global images: %s\n", err.Error())
+		c.globalImages = "unknown"
+	}
+
+	return c, nil
+}
+
+// getGlobalImagesRelease returns the tags of the Renku global images configured in a deployment
+func getGlobalImagesRelease(ctx context.Context, url string) (release string, err error) {
+	rsc, err := session.NewRenkuSessionClient(url)
+	if err != nil {
+		return "", err
+	}
+	envs, err := rsc.GetGlobalEnvironments(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	tags := []string{}
+	for _, env := range envs {
+		image, tag, _ := strings.Cut(env.ContainerImage, ":")
+		if !strings.HasPrefix(image, github.DefaultGlobalImagesPrefix) {
+			continue
+		}
+		if tag == "" {
+			tag = "latest"
+		}
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		return "none", nil
+	}
+	slices.Sort(tags)
+	return strings.Join(tags, ", "), nil
+}
+
+func (c deploymentComment) render() string {
+	var sb strings.Builder
+	fmt.Fprintln(&sb, prCommentMarker)
+	fmt.Fprintf(&sb, "### Deployment `%s`\n\n", c.namespace)
+	fmt.Fprintf(&sb, "- URL: %s\n", c.url)
+	health := "healthy :white_check_mark:"
+	if !c.healthy {
+		health = "unhealthy :x:"
+	}
+	fmt.Fprintf(&sb, "- Status: %s\n", health)
+	fmt.Fprintf(&sb, "- Global images release: %s\n", c.globalImages)
+
+	if len(c.images) > 0 {
+		fmt.Fprintln(&sb, "\n| Component | Image |")
+		fmt.Fprintln(&sb, "| --- | --- |")
+		components := make([]string, 0, len(c.images))
+		for component := range c.images {
+			components = append(components, component)
+		}
+		slices.Sort(components)
+		for _, component := range components {
+			fmt.Fprintf(&sb, "| %s | `%s` |\n", component, c.images[component])
+		}
+	}
+
+	fmt.Fprintln(&sb, "\n<sub>Updated by `rdu pr-comment`</sub>")
+	return sb.String()
+}
+
+func init() {
+	prCommentCmd.Flags().StringP("namespace", "n", "", "k8s namespace")
+	prCommentCmd.Flags().String("release", "renku", "helm release name")
+	prCommentCmd.Flags().Bool("dry-run", false, "print the comment instead of posting it")
+	prCommentCmd.Flags().Bool("force", false, "comment even if the namespace is not the deployment of the pull request")
+}
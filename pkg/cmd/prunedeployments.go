@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var pruneDeploymentsCmd = &cobra.Command{
+	Use:   "prune-deployments",
+	Short: "Cleanup all CI deployments whose pull request is merged or closed",
+	Run:   pruneDeployments,
+}
+
+type pruneCandidate struct {
+	namespace  string
+	repository string
+	pr         int
+	state      string
+}
+
+func pruneDeployments(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	deleteNamespace := viper.GetBool("delete-namespace")
+	dryRun := viper.GetBool("dry-run")
+
+	cli, err := github.NewGitHubClient(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	clients, err := k8s.GetClientset()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	client, err := k8s.GetDynamicClient()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	namespaceList, err := k8s.ListNamespaces(ctx, clients)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	candidates := []pruneCandidate{}
+	for i := range namespaceList.Items {
+		ns := namespaceList.Items[i]
+		repo, pr := github.MatchDeploymentNamespace(ns.Name)
+		if repo == "" {
+			continue
+		}
+		state, err := cli.GetPullRequestState(ctx, repo, pr)
+		if err != nil {
+			fmt.Printf("Skipping namespace '%s': %s\n", ns.Name, err)
+			continue
+		}
+		if state == "MERGED" || state == "CLOSED" {
+			candidates = append(candidates, pruneCandidate{namespace: ns.Name, repository: repo, pr: pr, state: state})
+		}
+	}
+
+	if len(candidates) == 0 {
+		fmt.Println("No deployments to prune")
+		return
+	}
+
+	fmt.Println("The following deployments will be cleaned up:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tREPOSITORY\tPR\tSTATE")
+	for _, candidate := range candidates {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", candidate.namespace, candidate.repository, candidate.pr, candidate.state)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if dryRun {
+		return
+	}
+
+	fmt.Println("This command will perform the following actions in each of these namespaces:")
+	printCleanupSteps(deleteNamespace)
+	proceed, err := askForConfirmation("Proceed?")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if !proceed {
+		os.Exit(0)
+	}
+
+	results := make([]error, len(candidates))
+	for i, candidate := range candidates {
+		fmt.Printf("Cleaning up namespace '%s'\n", candidate.namespace)
+		results[i] = cleanupNamespace(ctx, clients, client, candidate.namespace, deleteNamespace)
+		if results[i] != nil {
+			fmt.Println(results[i])
+		}
+	}
+
+	fmt.Println("Summary:")
+	failed := 0
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for i, candidate := range candidates {
+		if results[i] != nil {
+			failed++
+			fmt.Fprintf(w, "%s\tFAILED\t%s\n", candidate.namespace, results[i])
+		} else {
+			fmt.Fprintf(w, "%s\tOK\t\n", candidate.namespace)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if failed > 0 {
+		fmt.Printf("%d of %d deployments could not be cleaned up\n", failed, len(candidates))
+		os.Exit(1)
+	}
+}
+
+func init() {
+	pruneDeploymentsCmd.Flags().Bool("delete-namespace", false, "if set, the namespaces will be deleted")
+	pruneDeploymentsCmd.Flags().Bool("dry-run", false, "only print the deployments which would be cleaned up")
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var redeployCmd = &cobra.Command{
+	Use:   "redeploy",
+	Short: "Trigger a CI deployment of a pull request",
+	Long: fmt.Sprintf(`Trigger a CI deployment of a pull request.
+The "/deploy" command is posted as a comment on the pull request, or sent as an input of the workflow given with --workflow.
+The triggered run is then followed until it finishes: with --workflow, the run dispatched on the head branch of the pull request,
+otherwise, the run triggered by the comment, recognized by the pull request title shown as the run title.
+Known components: %s`, strings.Join(github.GetDeployComponents(), ", ")),
+	Run: redeploy,
+}
+
+func redeploy(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	repository := viper.GetString("repo")
+	pr := viper.GetInt("pr")
+	components := viper.GetStringSlice("component")
+	extraValues := viper.GetStringSlice("extra-values")
+	workflow := viper.GetString("workflow")
+	workflowInput := viper.GetString("workflow-input")
+	wait := !viper.GetBool("no-wait")
+	pollInterval := viper.GetDuration("poll-interval")
+	timeout := viper.GetDuration("timeout")
+	dryRun := viper.GetBool("dry-run")
+
+	deploy, err := github.NewDeployString(components, extraValues)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("Deploy string: %s\n", deploy)
+	if dryRun {
+		return
+	}
+
+	cli, err := github.NewGitHubClient(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if repository == "" {
+		repository, err = cli.GetCurrentRepository(ctx)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+	if pr == 0 {
+		pr, err = cli.GetCurrentPullRequest(ctx)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	pullRequest, err := cli.GetPullRequest(ctx, repository, pr)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	// Leave some room for the clock of GitHub being behind ours
+	since := time.Now().Add(-10 * time.Second)
+	event, branch := "issue_comment", ""
+	// Runs triggered by a comment run on the default branch, keep the ones of this pull request
+	match := github.MatchPullRequestRun(pullRequest)
+	if workflow != "" {
+		event, branch, match = "workflow_dispatch", pullRequest.HeadBranch, nil
+		err = cli.DispatchWorkflow(ctx, repository, workflow, branch, map[string]string{workflowInput: deploy.String()})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("Dispatched %s on %s (%s#%d)\n", workflow, branch, repository, pr)
+	} else {
+		err = cli.CreateIssueComment(ctx, repository, pr, deploy.String())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("Commented on %s#%d\n", repository, pr)
+	}
+
+	if !wait {
+		return
+	}
+
+	fmt.Println("Waiting for the workflow run to start...")
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	run, err := github.WaitForWorkflowRun(ctx, cli, repository, workflow, event, branch, since, pollInterval, match, func(run github.WorkflowRun) {
+		fmt.Printf("%s: %s %s\n", run.Name, strings.ReplaceAll(run.Status, "_", " "), run.URL)
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if run.Conclusion != "success" {
+		fmt.Printf("Workflow run '%s' finished with conclusion '%s'\n", run.Name, run.Conclusion)
+		os.Exit(1)
+	}
+	fmt.Printf("Workflow run '%s' succeeded\n", run.Name)
+}
+
+func init() {
+	redeployCmd.Flags().String("repo", "", "repository of the pull request (default: current repository)")
+	redeployCmd.Flags().Int("pr", 0, "pull request to deploy (default: pull request of the current branch)")
+	redeployCmd.Flags().StringSlice("component", []string{}, "component versions, e.g. renku-data-services=#123 or renku-ui=my-branch")
+	redeployCmd.Flags().StringSlice("extra-values", []string{}, "extra helm values, e.g. key=value")
+	redeployCmd.Flags().String("workflow", "", "workflow to dispatch instead of commenting on the pull request, e.g. deploy.yml")
+	redeployCmd.Flags().String("workflow-input", "deploy-string", "workflow input receiving the deploy string, used with --workflow")
+	redeployCmd.Flags().Bool("no-wait", false, "do not wait for the workflow run to finish")
+	redeployCmd.Flags().Duration("poll-interval", 15*time.Second, "interval between two checks of the workflow run")
+	redeployCmd.Flags().Duration("timeout", time.Hour, "time to wait for the workflow run to finish")
+	redeployCmd.Flags().Bool("dry-run", false, "print the deploy string without triggering the deployment")
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var releasesCmd = &cobra.Command{
+	Use:   "releases",
+	Short: "Manage the helm releases of a renku deployment",
+	RunE:  runReleases,
+}
+
+func runReleases(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+func init() {
+	releasesCmd.PersistentFlags().StringP("namespace", "n", "", "k8s namespace")
+
+	releasesCmd.AddCommand(releasesHistoryCmd)
+	releasesCmd.AddCommand(releasesRollbackCmd)
+}
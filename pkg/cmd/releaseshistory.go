@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var releasesHistoryCmd = &cobra.Command{
+	Use:   "history [release]",
+	Short: "Show the revisions of the helm releases of a deployment",
+	Long:  "Show the revisions of a helm release, or of all the helm releases of a deployment if none is given.",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   releasesHistory,
+}
+
+func releasesHistory(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	namespace := viper.GetString("namespace")
+
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	rm, err := newReleaseManager()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	names := args
+	if len(names) == 0 {
+		releases, err := rm.ListReleases(ctx, namespace)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if len(releases) == 0 {
+			fmt.Printf("No helm releases found in namespace '%s'\n", namespace)
+			return
+		}
+		for _, release := range releases {
+			names = append(names, release.Name)
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RELEASE\tREVISION\tUPDATED\tSTATUS\tCHART\tAPP VERSION\tDESCRIPTION")
+	for _, name := range names {
+		history, err := rm.GetHistory(ctx, namespace, name)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for _, revision := range history {
+			chart := fmt.Sprintf("%s-%s", revision.Chart, revision.ChartVersion)
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n", revision.Name, revision.Revision, revision.Updated.Local().Format(time.DateTime), revision.Status, chart, revision.AppVersion, revision.Description)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
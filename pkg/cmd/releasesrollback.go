@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var releasesRollbackCmd = &cobra.Command{
+	Use:   "rollback <release> [revision]",
+	Short: "Roll a helm release back to a previous revision",
+	Long:  "Roll a helm release back to a revision, the previous one if none is given.",
+	Args:  cobra.RangeArgs(1, 2),
+	Run:   releasesRollback,
+}
+
+func releasesRollback(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	namespace := viper.GetString("namespace")
+	wait := viper.GetBool("wait")
+	timeout := viper.GetDuration("timeout")
+
+	release := args[0]
+	revision := 0
+	if len(args) > 1 {
+		var err error
+		revision, err = strconv.Atoi(args[1])
+		if err != nil || revision <= 0 {
+			fmt.Printf("Invalid revision '%s'\n", args[1])
+			os.Exit(1)
+		}
+	}
+
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	rm, err := newReleaseManager()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	target := "the previous revision"
+	if revision > 0 {
+		target = fmt.Sprintf("revision %d", revision)
+	}
+	fmt.Printf("Rolling back release '%s' in namespace '%s' to %s\n", release, namespace, target)
+	err = rm.Rollback(ctx, namespace, release, revision, wait, timeout)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	history, err := rm.GetHistory(ctx, namespace, release)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(history) > 0 {
+		current := history[len(history)-1]
+		fmt.Printf("Release '%s' is now at revision %d: %s (chart %s-%s)\n", current.Name, current.Revision, current.Status, current.Chart, current.ChartVersion)
+	}
+}
+
+func init() {
+	releasesRollbackCmd.Flags().Bool("wait", false, "wait until the rolled back resources are ready")
+	releasesRollbackCmd.Flags().Duration("timeout", 5*time.Minute, "time to wait for the rollback")
+}
@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/helm"
+	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -23,19 +29,73 @@ func runRoot(cmd *cobra.Command, args []string) error {
 }
 
 func preRunRoot(cmd *cobra.Command, args []string) error {
-	return viper.BindPFlags(cmd.Flags())
+	err := viper.BindPFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	return github.LoadNamespaceMappings(viper.GetString("namespaces-config"))
+}
+
+// resolveNamespace returns the namespace of the deployment, from the first source of the resolution chain which has one
+func resolveNamespace(ctx context.Context, flagValue string) (namespace string, err error) {
+	resolver := ns.NewResolver(ns.DefaultSources(flagValue, getConfigPath("current-deployment"))...)
+	namespace, source, err := resolver.Resolve(ctx)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(os.Stderr, "Namespace: %s (from %s)\n", namespace, source)
+	return namespace, nil
+}
+
+// getConfigPath returns the path of a configuration file of rdu, or "" if there is no config directory
+func getConfigPath(name string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "renku-dev-utils", name)
+}
+
+// newReleaseManager returns the helm release manager selected with --helm-backend
+func newReleaseManager() (helm.ReleaseManager, error) {
+	return helm.NewReleaseManager(viper.GetString("helm-backend"))
+}
+
+// getCachePath returns the path of a cache file of rdu, or "" if there is no cache directory
+func getCachePath(name string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "renku-dev-utils", name)
 }
 
 func init() {
+	rootCmd.PersistentFlags().String("namespaces-config", getConfigPath("namespaces.yaml"), "config file mapping repositories to deployment namespaces")
+	rootCmd.PersistentFlags().String("helm-backend", helm.BackendSDK, "how to manage helm releases: 'sdk' (in-process) or 'cli' (helm binary)")
+
+	rootCmd.AddCommand(authCmd)
 	rootCmd.AddCommand(cleanupDeploymentCmd)
 	rootCmd.AddCommand(copyKeycloakAdminPasswordCmd)
+	rootCmd.AddCommand(deployCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(linkedPRsCmd)
 	rootCmd.AddCommand(listDeploymentsCmd)
 	rootCmd.AddCommand(loginCmd)
 	rootCmd.AddCommand(logoutCmd)
 	rootCmd.AddCommand(makeMeAdminCmd)
 	rootCmd.AddCommand(namespaceCmd)
 	rootCmd.AddCommand(openDeploymentCmd)
+	rootCmd.AddCommand(portForwardCmd)
+	rootCmd.AddCommand(prCommentCmd)
+	rootCmd.AddCommand(pruneDeploymentsCmd)
+	rootCmd.AddCommand(redeployCmd)
+	rootCmd.AddCommand(releasesCmd)
+	rootCmd.AddCommand(sessionsCmd)
+	rootCmd.AddCommand(setImageCmd)
+	rootCmd.AddCommand(statusCmd)
 	rootCmd.AddCommand(updateGlobalImagesCmd)
+	rootCmd.AddCommand(useCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
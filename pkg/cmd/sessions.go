@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Manage the sessions of a renku deployment",
+	RunE:  runSessions,
+}
+
+func runSessions(cmd *cobra.Command, args []string) error {
+	return cmd.Help()
+}
+
+func init() {
+	sessionsCmd.PersistentFlags().StringP("namespace", "n", "", "k8s namespace")
+
+	sessionsCmd.AddCommand(sessionsDeleteCmd)
+	sessionsCmd.AddCommand(sessionsHibernateCmd)
+	sessionsCmd.AddCommand(sessionsListCmd)
+	sessionsCmd.AddCommand(sessionsLogsCmd)
+	sessionsCmd.AddCommand(sessionsResumeCmd)
+}
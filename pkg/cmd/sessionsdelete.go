@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+var sessionsDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a session",
+	Args:    cobra.ExactArgs(1),
+	Run:     sessionsDelete,
+}
+
+func sessionsDelete(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	name := args[0]
+	namespace := viper.GetString("namespace")
+	force := viper.GetBool("force")
+	gracePeriod := viper.GetDuration("grace-period")
+
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	client, err := k8s.GetDynamicClient()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	kind, err := k8s.FindSessionKind(ctx, client, namespace, name)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	switch kind {
+	case k8s.AmaltheaSessionKind:
+		err = k8s.DeleteAmaltheaSession(ctx, client, namespace, name, nil)
+	case k8s.JupyterServerKind:
+		err = k8s.DeleteJupyterServer(ctx, client, namespace, name, nil)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if !force {
+		fmt.Printf("Deleted %s '%s'\n", kind, name)
+		return
+	}
+
+	// Give the operator a chance to clean up before removing the finalizers
+	fmt.Printf("Waiting up to %s for %s '%s' to be deleted\n", gracePeriod, kind, name)
+	deleted, err := k8s.WaitForSessionDeletion(ctx, client, namespace, name, kind, gracePeriod)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if deleted {
+		fmt.Printf("Deleted %s '%s'\n", kind, name)
+		return
+	}
+
+	switch kind {
+	case k8s.AmaltheaSessionKind:
+		err = k8s.ForciblyDeleteAmaltheaSession(ctx, client, namespace, name, nil)
+	case k8s.JupyterServerKind:
+		err = k8s.ForciblyDeleteJupyterServer(ctx, client, namespace, name, nil)
+	}
+	if err != nil && k8serrors.IsNotFound(err) {
+		fmt.Printf("Deleted %s '%s'\n", kind, name)
+		return
+	} else if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("Forcibly deleted %s '%s'\n", kind, name)
+}
+
+func init() {
+	sessionsDeleteCmd.Flags().Bool("force", false, "remove the finalizers of the session if it is not deleted within --grace-period")
+	sessionsDeleteCmd.Flags().Duration("grace-period", 30*time.Second, "time to wait for a graceful deletion before removing the finalizers, used with --force")
+}
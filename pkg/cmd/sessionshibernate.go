@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var sessionsHibernateCmd = &cobra.Command{
+	Use:   "hibernate <name>",
+	Short: "Hibernate a session",
+	Args:  cobra.ExactArgs(1),
+	Run:   sessionsHibernate,
+}
+
+var sessionsResumeCmd = &cobra.Command{
+	Use:   "resume <name>",
+	Short: "Resume a hibernated session",
+	Args:  cobra.ExactArgs(1),
+	Run:   sessionsResume,
+}
+
+func sessionsHibernate(cmd *cobra.Command, args []string) {
+	setSessionHibernated(cmd, args[0], true)
+}
+
+func sessionsResume(cmd *cobra.Command, args []string) {
+	setSessionHibernated(cmd, args[0], false)
+}
+
+func setSessionHibernated(cmd *cobra.Command, name string, hibernated bool) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	namespace := viper.GetString("namespace")
+
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	client, err := k8s.GetDynamicClient()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	kind, err := k8s.FindSessionKind(ctx, client, namespace, name)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	switch kind {
+	case k8s.AmaltheaSessionKind:
+		err = k8s.SetAmaltheaSessionHibernated(ctx, client, namespace, name, hibernated, nil)
+	case k8s.JupyterServerKind:
+		err = k8s.SetJupyterServerHibernated(ctx, client, namespace, name, hibernated, nil)
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if hibernated {
+		fmt.Printf("Hibernated %s '%s'\n", kind, name)
+	} else {
+		fmt.Printf("Resumed %s '%s'\n", kind, name)
+	}
+}
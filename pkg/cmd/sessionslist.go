@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+var sessionsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the sessions of a renku deployment",
+	Run:     sessionsList,
+}
+
+func sessionsList(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	namespace := viper.GetString("namespace")
+	output := viper.GetString("output")
+
+	if output != "table" && output != "json" {
+		fmt.Printf("Invalid output format '%s', expected 'table' or 'json'\n", output)
+		os.Exit(1)
+	}
+
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	client, err := k8s.GetDynamicClient()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	sessions, err := k8s.ListSessions(ctx, client, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if output == "json" {
+		if sessions == nil {
+			sessions = []k8s.SessionInfo{}
+		}
+		out, err := json.MarshalIndent(sessions, "", "  ")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println(string(out))
+		return
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tOWNER\tSTATE\tAGE\tRESOURCE CLASS\tIMAGE")
+	for _, session := range sessions {
+		age := duration.HumanDuration(now.Sub(session.CreatedAt))
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", session.Kind, session.Name, session.Owner, session.State, age, session.ResourceClass, session.Image)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	sessionsListCmd.Flags().StringP("output", "o", "table", "output format (table or json)")
+}
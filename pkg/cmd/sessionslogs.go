@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var sessionsLogsCmd = &cobra.Command{
+	Use:   "logs <name>",
+	Short: "Print the logs of a session",
+	Args:  cobra.ExactArgs(1),
+	Run:   sessionsLogs,
+}
+
+func sessionsLogs(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	name := args[0]
+	namespace := viper.GetString("namespace")
+	container := viper.GetString("container")
+	follow := viper.GetBool("follow")
+	previous := viper.GetBool("previous")
+	tail := viper.GetInt64("tail")
+
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	clients, err := k8s.GetClientset()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	client, err := k8s.GetDynamicClient()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	pods, err := k8s.FindSessionPods(ctx, clients, client, namespace, name)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	pod := pods[0]
+	if len(pods) > 1 {
+		fmt.Printf("Found %d pods, using '%s'\n", len(pods), pod.Name)
+	}
+
+	if container == "" {
+		container, err = selectContainer(k8s.GetPodContainers(&pod))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	opts := &corev1.PodLogOptions{
+		Container: container,
+		Follow:    follow,
+		Previous:  previous,
+	}
+	if tail >= 0 {
+		opts.TailLines = &tail
+	}
+	err = k8s.StreamPodLogs(ctx, clients, namespace, pod.Name, opts, os.Stdout)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func selectContainer(containers []k8s.PodContainer) (container string, err error) {
+	if len(containers) == 1 {
+		return containers[0].Name, nil
+	}
+
+	fmt.Println("Containers:")
+	for i, c := range containers {
+		if c.Init {
+			fmt.Printf("  %d. %s (init)\n", i+1, c.Name)
+		} else {
+			fmt.Printf("  %d. %s\n", i+1, c.Name)
+		}
+	}
+	fmt.Printf("Select a container (1-%d): ", len(containers))
+
+	reader := bufio.NewReader(os.Stdin)
+	res, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	res = strings.TrimSpace(res)
+	idx, err := strconv.Atoi(res)
+	if err != nil || idx < 1 || idx > len(containers) {
+		return "", fmt.Errorf("invalid selection '%s', aborting", res)
+	}
+	return containers[idx-1].Name, nil
+}
+
+func init() {
+	sessionsLogsCmd.Flags().StringP("container", "c", "", "container name, prompted for if not set")
+	sessionsLogsCmd.Flags().BoolP("follow", "f", false, "follow the logs")
+	sessionsLogsCmd.Flags().BoolP("previous", "p", false, "print the logs of the previous container instance")
+	sessionsLogsCmd.Flags().Int64("tail", -1, "number of lines to show from the end of the logs, all lines if negative")
+}
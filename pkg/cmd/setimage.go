@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/helm"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/oci"
+	"github.com/distribution/reference"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var setImageCmd = &cobra.Command{
+	Use:   "set-image <component=image:tag>...",
+	Short: "Change the image of renku components in a deployment",
+	Long: fmt.Sprintf(`Change the image of renku components in a deployment, keeping the other values of the helm release.
+The release is upgraded with the chart and version it was deployed with, from --chart-repo, or with --chart.
+Known components: %s`, strings.Join(helm.GetComponents(), ", ")),
+	Args: cobra.MinimumNArgs(1),
+	Run:  setImage,
+}
+
+func setImage(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	namespace := viper.GetString("namespace")
+	release := viper.GetString("release")
+	timeout := viper.GetDuration("timeout")
+	chart := helm.ChartRef{
+		Name:    viper.GetString("chart"),
+		RepoURL: viper.GetString("chart-repo"),
+		Version: viper.GetString("version"),
+	}
+
+	// Local charts do not come from the chart repository
+	if info, err := os.Stat(chart.Name); err == nil && info.IsDir() {
+		chart.RepoURL = ""
+	}
+
+	images := map[string]string{}
+	components := []string{}
+	for _, arg := range args {
+		component, image, found := strings.Cut(arg, "=")
+		if !found {
+			fmt.Printf("Invalid image '%s', expected component=image:tag\n", arg)
+			os.Exit(1)
+		}
+		if _, exists := images[component]; !exists {
+			components = append(components, component)
+		}
+		images[component] = image
+	}
+
+	rc, err := oci.NewRegistryClient()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	for _, component := range components {
+		named, err := reference.ParseDockerRef(images[component])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		_, err = rc.CheckImage(ctx, named)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	namespace, err = resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	rm, err := newReleaseManager()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	values, err := rm.GetValues(ctx, namespace, release, false)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	for _, component := range components {
+		err = helm.SetComponentImage(values, component, images[component])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	clients, err := k8s.GetClientset()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	before, err := k8s.ListWorkloadStatuses(ctx, clients, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Upgrading release '%s' in namespace '%s':\n", release, namespace)
+	for _, component := range components {
+		fmt.Printf("  %s: %s\n", component, images[component])
+	}
+	result, err := helm.UpgradeValues(ctx, rm, namespace, release, chart, values, timeout)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("Release '%s' revision %d: %s\n", result.Name, result.Revision, result.Status)
+
+	after, err := k8s.ListWorkloadStatuses(ctx, clients, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	rolled := getRolledWorkloads(before, after)
+	if len(rolled) == 0 {
+		fmt.Println("No workload rolled")
+		return
+	}
+	fmt.Println("Rolled workloads:")
+	for _, workload := range rolled {
+		fmt.Printf("  %s/%s\n", workload.Kind, workload.Name)
+	}
+}
+
+// getRolledWorkloads returns the workloads whose spec changed, or which were created
+func getRolledWorkloads(before []k8s.WorkloadStatus, after []k8s.WorkloadStatus) (rolled []k8s.WorkloadStatus) {
+	generations := map[string]int64{}
+	for _, workload := range before {
+		generations[workload.Kind+"/"+workload.Name] = workload.Generation
+	}
+	for _, workload := range after {
+		generation, found := generations[workload.Kind+"/"+workload.Name]
+		if !found || generation != workload.Generation {
+			rolled = append(rolled, workload)
+		}
+	}
+	return rolled
+}
+
+func init() {
+	setImageCmd.Flags().StringP("namespace", "n", "", "k8s namespace")
+	setImageCmd.Flags().String("release", "renku", "helm release name")
+	setImageCmd.Flags().String("chart", "", "chart name, or a path to a local chart (default: the deployed chart)")
+	setImageCmd.Flags().String("chart-repo", defaultChartRepository, "chart repository URL")
+	setImageCmd.Flags().String("version", "", "chart version, used with --chart (default: latest)")
+	setImageCmd.Flags().Duration("timeout", 10*time.Minute, "time to wait for the deployment to be ready")
+}
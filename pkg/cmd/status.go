@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/helm"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/client-go/kubernetes"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the health of a renku deployment",
+	Long:  "Show the health of a renku deployment.\nExits with a non-zero code if the deployment is unhealthy.",
+	Run:   statusFn,
+}
+
+// deploymentStatus holds the health checks of a deployment
+type deploymentStatus struct {
+	namespace     string
+	url           string
+	urlStatus     string
+	urlOK         bool
+	releases      []helm.Release
+	workloads     []k8s.WorkloadStatus
+	unhealthyPods []k8s.UnhealthyPod
+	failedJobs    []string
+}
+
+func (s deploymentStatus) isHealthy() bool {
+	if !s.urlOK || len(s.unhealthyPods) > 0 || len(s.failedJobs) > 0 {
+		return false
+	}
+	for _, release := range s.releases {
+		if release.Status != "deployed" {
+			return false
+		}
+	}
+	for _, workload := range s.workloads {
+		if !workload.IsReady() {
+			return false
+		}
+	}
+	return true
+}
+
+func statusFn(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	namespace := viper.GetString("namespace")
+
+	namespace, err := resolveNamespace(ctx, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	clients, err := k8s.GetClientset()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	deployment, err := collectDeploymentStatus(ctx, clients, namespace)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	err = printDeploymentStatus(deployment)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if !deployment.isHealthy() {
+		fmt.Printf("Deployment '%s' is unhealthy\n", namespace)
+		os.Exit(1)
+	}
+	fmt.Printf("Deployment '%s' is healthy\n", namespace)
+}
+
+func collectDeploymentStatus(ctx context.Context, clients *kubernetes.Clientset, namespace string) (s deploymentStatus, err error) {
+	s.namespace = namespace
+
+	releaseManager, err := newReleaseManager()
+	if err != nil {
+		return s, err
+	}
+	s.releases, err = releaseManager.ListReleases(ctx, namespace)
+	if err != nil {
+		return s, err
+	}
+
+	s.workloads, err = k8s.ListWorkloadStatuses(ctx, clients, namespace)
+	if err != nil {
+		return s, err
+	}
+
+	s.unhealthyPods, err = k8s.ListUnhealthyPods(ctx, clients, namespace)
+	if err != nil {
+		return s, err
+	}
+
+	s.failedJobs, err = k8s.ListFailedJobs(ctx, clients, namespace)
+	if err != nil {
+		return s, err
+	}
+
+	deploymentURL, err := ns.ResolveDeploymentURL(ctx, namespace)
+	if err != nil {
+		return s, err
+	}
+	s.url = deploymentURL.String()
+	s.urlStatus, s.urlOK = checkURL(ctx, s.url)
+
+	return s, nil
+}
+
+func checkURL(ctx context.Context, url string) (status string, ok bool) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err.Error(), false
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err.Error(), false
+	}
+	if err := res.Body.Close(); err != nil {
+		fmt.Printf("Warning, could not close HTTP response: %s", err.Error())
+	}
+	return res.Status, res.StatusCode >= 200 && res.StatusCode < 400
+}
+
+func printDeploymentStatus(s deploymentStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Namespace:\t%s\n", s.namespace)
+	fmt.Fprintf(w, "URL:\t%s\t%s\t%s\n", s.url, s.urlStatus, okOrFailed(s.urlOK))
+
+	fmt.Fprintln(w, "\nHelm releases:")
+	fmt.Fprintln(w, "  NAME\tCHART\tREVISION\tSTATUS\t")
+	for _, release := range s.releases {
+		fmt.Fprintf(w, "  %s\t%s-%s\t%d\t%s\t%s\n", release.Name, release.Chart, release.ChartVersion, release.Revision, release.Status, okOrFailed(release.Status == "deployed"))
+	}
+
+	fmt.Fprintln(w, "\nWorkloads:")
+	fmt.Fprintln(w, "  KIND\tNAME\tREADY\t")
+	for _, workload := range s.workloads {
+		fmt.Fprintf(w, "  %s\t%s\t%d/%d\t%s\n", workload.Kind, workload.Name, workload.Ready, workload.Desired, okOrFailed(workload.IsReady()))
+	}
+
+	if len(s.unhealthyPods) > 0 {
+		fmt.Fprintln(w, "\nUnhealthy pods:")
+		fmt.Fprintln(w, "  NAME\tREASON\tLAST EVENT")
+		for _, pod := range s.unhealthyPods {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", pod.Name, pod.Reason, pod.LastEvent)
+		}
+	}
+
+	if len(s.failedJobs) > 0 {
+		fmt.Fprintln(w, "\nFailed jobs:")
+		for _, job := range s.failedJobs {
+			fmt.Fprintf(w, "  %s\n", job)
+		}
+	}
+
+	fmt.Fprintln(w)
+	return w.Flush()
+}
+
+func okOrFailed(ok bool) string {
+	if ok {
+		return "OK"
+	}
+	return "FAILED"
+}
+
+func init() {
+	statusCmd.Flags().StringP("namespace", "n", "", "k8s namespace")
+}
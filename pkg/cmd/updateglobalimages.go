@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
 	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/oci"
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/renkuapi"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -28,13 +30,19 @@ func updateGlobalImages(cmd *cobra.Command, args []string) {
 	namespace := viper.GetString("namespace")
 	release := viper.GetString("release")
 	dryRun := viper.GetBool("dry-run")
+	imagesPrefix := viper.GetString("images-prefix")
 
-	if release == "" {
-		cli, err := github.NewGitHubCLI("")
+	var cli github.GitHubClient
+	var err error
+	if release == "" || strings.HasPrefix(imagesPrefix, "ghcr.io/") {
+		cli, err = github.NewGitHubClient(ctx)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
+	}
+
+	if release == "" {
 		release, err = cli.GetLatestRenkuRelease(ctx)
 		if err != nil {
 			fmt.Println(err)
@@ -44,21 +52,26 @@ func updateGlobalImages(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("Renku release: %s\n", release)
 
+	rc, err := oci.NewRegistryClient()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	images, err := github.DiscoverGlobalImages(ctx, cli, rc, imagesPrefix, release)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("Global images: %s\n", strings.Join(images, ", "))
+
 	if url == "" {
-		if namespace == "" {
-			cli, err := github.NewGitHubCLI("")
-			if err != nil {
-				fmt.Println(err)
-				os.Exit(1)
-			}
-			namespace, err = ns.FindCurrentNamespace(ctx, cli)
-			if err != nil {
-				fmt.Println(err)
-				os.Exit(1)
-			}
+		namespace, err = resolveNamespace(ctx, namespace)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
 
-		deploymentURL, err := ns.GetDeploymentURL(namespace)
+		deploymentURL, err := ns.ResolveDeploymentURL(ctx, namespace)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -106,7 +119,7 @@ func updateGlobalImages(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	err = rsc.UpdateGlobalImages(ctx, github.GetGlobalImages(), release, envs, dryRun)
+	err = rsc.UpdateGlobalImages(ctx, images, release, envs, dryRun)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -118,4 +131,5 @@ func init() {
 	updateGlobalImagesCmd.Flags().StringP("namespace", "n", "", "k8s namespace")
 	updateGlobalImagesCmd.Flags().String("release", "", "renku release")
 	updateGlobalImagesCmd.Flags().Bool("dry-run", false, "dry run")
+	updateGlobalImagesCmd.Flags().String("images-prefix", github.DefaultGlobalImagesPrefix, "prefix of the global images, every image starting with it and tagged with the release is updated")
 }
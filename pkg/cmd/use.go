@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	ns "github.com/SwissDataScienceCenter/renku-dev-utils/pkg/namespace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var useCmd = &cobra.Command{
+	Use:   "use [namespace]",
+	Short: "Set the deployment used by default by the other commands",
+	Long: fmt.Sprintf(`Set the deployment used by default by the other commands.
+The namespace of a deployment is taken from, in order:
+  1. the --namespace flag
+  2. the %s environment variable
+  3. the deployment set with "rdu use"
+  4. the pull request of the current git branch
+  5. the namespace of the current kube context
+  6. an interactive selection, when running in a terminal
+Without argument, print the current deployment.`, ns.NamespaceEnvVar),
+	Args: cobra.MaximumNArgs(1),
+	Run:  use,
+}
+
+func use(cmd *cobra.Command, args []string) {
+	clearDeployment := viper.GetBool("clear")
+	path := getConfigPath("current-deployment")
+
+	if clearDeployment {
+		err := ns.SetCurrentDeployment(path, "")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Println("Cleared the current deployment")
+		return
+	}
+
+	if len(args) == 0 {
+		namespace, err := ns.GetCurrentDeployment(path)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if namespace == "" {
+			fmt.Println("No current deployment set")
+			return
+		}
+		fmt.Printf("%s\n", namespace)
+		return
+	}
+
+	err := ns.SetCurrentDeployment(path, args[0])
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("Current deployment: %s\n", args[0])
+}
+
+func init() {
+	useCmd.Flags().Bool("clear", false, "clear the current deployment")
+}
@@ -0,0 +1,390 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/executils"
+)
+
+const defaultAPIURL = "https://api.github.com"
+
+// Matches the next page in a Link header
+var nextLinkRegex = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// Matches the repository in a git remote URL, e.g. git@github.com:owner/name.git
+var remoteRepositoryRegex = regexp.MustCompile(`github\.com[:/]([^/]+/[^/]+?)(\.git)?/?$`)
+
+// GitHubAPI queries the GitHub REST API
+type GitHubAPI struct {
+	baseURL string
+	token   string
+	client  *http.Client
+
+	// runs git in the working directory
+	runGit func(ctx context.Context, arg ...string) ([]byte, error)
+}
+
+// NewGitHubAPI returns a client of the GitHub API at baseURL, https://api.github.com if empty
+func NewGitHubAPI(baseURL string, token string) (api *GitHubAPI, err error) {
+	if baseURL == "" {
+		baseURL = defaultAPIURL
+	}
+	_, err = url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &GitHubAPI{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  http.DefaultClient,
+		runGit:  runGit,
+	}, nil
+}
+
+func runGit(ctx context.Context, arg ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", arg...)
+	return executils.FormatOutput(cmd.Output())
+}
+
+func (api *GitHubAPI) GetCurrentRepository(ctx context.Context) (string, error) {
+	out, err := api.runGit(ctx, "remote", "get-url", "origin")
+	if err != nil {
+		return "", err
+	}
+	remote := strings.TrimSpace(string(out))
+	match := remoteRepositoryRegex.FindStringSubmatch(remote)
+	if match == nil {
+		return "", fmt.Errorf("the git remote '%s' is not a GitHub repository", remote)
+	}
+	return match[1], nil
+}
+
+func (api *GitHubAPI) GetCurrentPullRequest(ctx context.Context) (int, error) {
+	repository, err := api.GetCurrentRepository(ctx)
+	if err != nil {
+		return 0, err
+	}
+	owner, branch, err := api.getPushBranch(ctx, repository)
+	if err != nil {
+		return 0, err
+	}
+
+	query := url.Values{"head": {fmt.Sprintf("%s:%s", owner, branch)}, "state": {"all"}}
+	var pulls []gitHubPullRequest
+	_, err = api.get(ctx, fmt.Sprintf("/repos/%s/pulls?%s", repository, query.Encode()), &pulls)
+	if err != nil {
+		return 0, err
+	}
+	if len(pulls) == 0 {
+		return 0, fmt.Errorf("no pull requests found for branch \"%s\"", branch)
+	}
+	// Prefer an open pull request, the most recent one otherwise
+	for _, pull := range pulls {
+		if pull.State == "open" {
+			return pull.Number, nil
+		}
+	}
+	return pulls[0].Number, nil
+}
+
+// getPushBranch returns the owner of the repository and the name of the branch which the current branch is pushed to,
+// so that pull requests from forks are found
+func (api *GitHubAPI) getPushBranch(ctx context.Context, repository string) (owner string, branch string, err error) {
+	out, err := api.runGit(ctx, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", "", err
+	}
+	branch = strings.TrimSpace(string(out))
+	owner, _, _ = strings.Cut(repository, "/")
+
+	// e.g. "fork/feat-branch", fails if the branch is not pushed anywhere
+	out, err = api.runGit(ctx, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{push}")
+	if err != nil {
+		return owner, branch, nil
+	}
+	remote, pushBranch, found := strings.Cut(strings.TrimSpace(string(out)), "/")
+	if !found {
+		return owner, branch, nil
+	}
+	out, err = api.runGit(ctx, "remote", "get-url", "--push", remote)
+	if err != nil {
+		return owner, branch, nil
+	}
+	match := remoteRepositoryRegex.FindStringSubmatch(strings.TrimSpace(string(out)))
+	if match == nil {
+		return owner, branch, nil
+	}
+	pushOwner, _, _ := strings.Cut(match[1], "/")
+	return pushOwner, pushBranch, nil
+}
+
+func (api *GitHubAPI) GetPullRequest(ctx context.Context, repository string, pr int) (pullRequest PullRequest, err error) {
+	var pull gitHubPullRequest
+	_, err = api.get(ctx, fmt.Sprintf("/repos/%s/pulls/%d", repository, pr), &pull)
+	if err != nil {
+		return pullRequest, err
+	}
+	state := strings.ToUpper(pull.State)
+	if pull.Merged || pull.MergedAt != "" {
+		state = "MERGED"
+	}
+	return PullRequest{
+		Repository: repository,
+		Number:     pull.Number,
+		Title:      pull.Title,
+		Author:     pull.User.Login,
+		State:      state,
+		HeadBranch: pull.Head.Ref,
+		HeadSHA:    pull.Head.SHA,
+		Body:       pull.Body,
+	}, nil
+}
+
+func (api *GitHubAPI) GetPullRequestState(ctx context.Context, repository string, pr int) (state string, err error) {
+	pullRequest, err := api.GetPullRequest(ctx, repository, pr)
+	if err != nil {
+		return "", err
+	}
+	return pullRequest.State, nil
+}
+
+func (api *GitHubAPI) ListClosedPullRequests(ctx context.Context, repository string, limit int) (numbers []int, err error) {
+	path := fmt.Sprintf("/repos/%s/pulls?state=closed&sort=updated&direction=desc&per_page=100", repository)
+	for path != "" && len(numbers) < limit {
+		var page []gitHubPullRequest
+		path, err = api.get(ctx, path, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, pull := range page {
+			numbers = append(numbers, pull.Number)
+		}
+	}
+	if len(numbers) > limit {
+		numbers = numbers[:limit]
+	}
+	return numbers, nil
+}
+
+type gitHubPullRequest struct {
+	Number   int        `json:"number"`
+	Title    string     `json:"title"`
+	User     gitHubUser `json:"user"`
+	State    string     `json:"state"`
+	Merged   bool       `json:"merged"`
+	MergedAt string     `json:"merged_at"`
+	Body     string     `json:"body"`
+	Head     struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+type gitHubUser struct {
+	Login string `json:"login"`
+}
+
+func (api *GitHubAPI) GetCurrentUser(ctx context.Context) (string, error) {
+	var user gitHubUser
+	_, err := api.get(ctx, "/user", &user)
+	if err != nil {
+		return "", err
+	}
+	return user.Login, nil
+}
+
+func (api *GitHubAPI) GetLatestRenkuRelease(ctx context.Context) (string, error) {
+	var release gitHubRelease
+	_, err := api.get(ctx, fmt.Sprintf("/repos/%s/releases/latest", renkuRepository), &release)
+	if err != nil {
+		return "", err
+	}
+	return release.TagName, nil
+}
+
+type gitHubRelease struct {
+	TagName string `json:"tag_name"`
+}
+
+func (api *GitHubAPI) ListContainerPackages(ctx context.Context, org string, prefix string) (packages []string, err error) {
+	path := fmt.Sprintf("/orgs/%s/packages?package_type=container&per_page=100", org)
+	for path != "" {
+		var page []gitHubPackage
+		path, err = api.get(ctx, path, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, pkg := range page {
+			if strings.HasPrefix(pkg.Name, prefix) {
+				packages = append(packages, pkg.Name)
+			}
+		}
+	}
+	return packages, nil
+}
+
+func (api *GitHubAPI) GetCheckStatus(ctx context.Context, repository string, ref string) (status string, err error) {
+	runs := []gitHubCheckRun{}
+	path := fmt.Sprintf("/repos/%s/commits/%s/check-runs?per_page=100", repository, url.PathEscape(ref))
+	for path != "" {
+		var page gitHubCheckRuns
+		path, err = api.get(ctx, path, &page)
+		if err != nil {
+			return "", err
+		}
+		runs = append(runs, page.CheckRuns...)
+	}
+	return summarizeCheckRuns(runs), nil
+}
+
+func (api *GitHubAPI) ListIssueComments(ctx context.Context, repository string, number int) (comments []IssueComment, err error) {
+	path := fmt.Sprintf("/repos/%s/issues/%d/comments?per_page=100", repository, number)
+	for path != "" {
+		var page []IssueComment
+		path, err = api.get(ctx, path, &page)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, page...)
+	}
+	return comments, nil
+}
+
+func (api *GitHubAPI) CreateIssueComment(ctx context.Context, repository string, number int, body string) error {
+	_, err := api.do(ctx, "POST", fmt.Sprintf("/repos/%s/issues/%d/comments", repository, number), gitHubCommentBody{Body: body}, nil)
+	return err
+}
+
+func (api *GitHubAPI) UpdateIssueComment(ctx context.Context, repository string, commentID int64, body string) error {
+	_, err := api.do(ctx, "PATCH", fmt.Sprintf("/repos/%s/issues/comments/%d", repository, commentID), gitHubCommentBody{Body: body}, nil)
+	return err
+}
+
+func (api *GitHubAPI) DispatchWorkflow(ctx context.Context, repository string, workflow string, ref string, inputs map[string]string) error {
+	body := gitHubWorkflowDispatch{Ref: ref, Inputs: inputs}
+	_, err := api.do(ctx, "POST", fmt.Sprintf("/repos/%s/actions/workflows/%s/dispatches", repository, url.PathEscape(workflow)), body, nil)
+	return err
+}
+
+func (api *GitHubAPI) ListWorkflowRuns(ctx context.Context, repository string, workflow string, event string, branch string) (runs []WorkflowRun, err error) {
+	var res gitHubWorkflowRuns
+	_, err = api.get(ctx, getWorkflowRunsPath(repository, workflow, event, branch), &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.WorkflowRuns, nil
+}
+
+func (api *GitHubAPI) GetWorkflowRun(ctx context.Context, repository string, runID int64) (run WorkflowRun, err error) {
+	_, err = api.get(ctx, fmt.Sprintf("/repos/%s/actions/runs/%d", repository, runID), &run)
+	return run, err
+}
+
+type gitHubWorkflowDispatch struct {
+	Ref    string            `json:"ref"`
+	Inputs map[string]string `json:"inputs,omitempty"`
+}
+
+type gitHubCommentBody struct {
+	Body string `json:"body"`
+}
+
+type gitHubPackage struct {
+	Name string `json:"name"`
+}
+
+// get decodes the response to a GET request on path into out and returns the URL of the next page, if any.
+// path is relative to the API URL, or the absolute URL of a next page.
+func (api *GitHubAPI) get(ctx context.Context, path string, out any) (next string, err error) {
+	return api.do(ctx, "GET", path, nil, out)
+}
+
+// do sends a request with an optional JSON body, decodes the response into out
+// and returns the URL of the next page, if any
+func (api *GitHubAPI) do(ctx context.Context, method string, path string, body any, out any) (next string, err error) {
+	requestURL := path
+	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		requestURL = api.baseURL + path
+	}
+
+	var requestBody io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return "", err
+		}
+		requestBody = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, requestBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if api.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", api.token))
+	}
+
+	res, err := api.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			fmt.Printf("Warning, could not close response body: %s", err.Error())
+		}
+	}()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		apiErr := &APIError{Path: req.URL.Path, StatusCode: res.StatusCode}
+		var body gitHubError
+		if json.NewDecoder(res.Body).Decode(&body) == nil {
+			apiErr.Message = body.Message
+		}
+		return "", apiErr
+	}
+
+	if out != nil {
+		err = json.NewDecoder(res.Body).Decode(out)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	match := nextLinkRegex.FindStringSubmatch(res.Header.Get("Link"))
+	if match == nil {
+		return "", nil
+	}
+	return match[1], nil
+}
+
+// APIError is an error response of the GitHub API
+type APIError struct {
+	Path       string
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("GitHub API error on %s: %s (HTTP %d)", e.Path, e.Message, e.StatusCode)
+	}
+	return fmt.Sprintf("GitHub API error on %s: HTTP %d", e.Path, e.StatusCode)
+}
+
+type gitHubError struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,217 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeToken = "fake-token"
+
+// newTestGitHubAPI returns a client of a fake GitHub API served by handler, for requests authenticated with fakeToken
+func newTestGitHubAPI(t *testing.T, handler http.Handler) *GitHubAPI {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+fakeToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			writeJSON(t, w, map[string]string{"message": "Bad credentials"})
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	api, err := NewGitHubAPI(srv.URL, fakeToken)
+	require.NoError(t, err)
+	return api
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
+
+func writeNotFound(t *testing.T, w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotFound)
+	writeJSON(t, w, map[string]string{"message": "Not Found"})
+}
+
+// newFakeGitHub returns a client of a fake GitHub API serving a few pull requests, a user, a release and packages,
+// from a clone of SwissDataScienceCenter/renku-ui on the feat-branch branch
+func newFakeGitHub(t *testing.T) *GitHubAPI {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	pulls := map[string]map[string]any{
+		"1": {"number": 1, "title": "Add the session launcher", "user": map[string]string{"login": "alice"}, "state": "open", "merged": false, "body": "/deploy renku-data-services=#5", "head": map[string]string{"ref": "feat-branch", "sha": "aaa"}},
+		"2": {"number": 2, "state": "closed", "merged": true, "merged_at": "2025-01-01T00:00:00Z"},
+		"3": {"number": 3, "state": "closed", "merged": false},
+	}
+	mux.HandleFunc("GET /repos/SwissDataScienceCenter/renku-ui/pulls/{number}", func(w http.ResponseWriter, r *http.Request) {
+		pull, found := pulls[r.PathValue("number")]
+		if !found {
+			writeNotFound(t, w)
+			return
+		}
+		writeJSON(t, w, pull)
+	})
+	mux.HandleFunc("GET /repos/SwissDataScienceCenter/renku-ui/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") == "closed" {
+			writeJSON(t, w, []any{pulls["3"], pulls["2"]})
+			return
+		}
+		switch r.URL.Query().Get("head") {
+		case "SwissDataScienceCenter:feat-branch":
+			writeJSON(t, w, []any{pulls["3"], pulls["1"]})
+		case "alice:fork-branch":
+			writeJSON(t, w, []any{pulls["2"]})
+		default:
+			writeJSON(t, w, []any{})
+		}
+	})
+	mux.HandleFunc("GET /user", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]string{"login": "alice"})
+	})
+	mux.HandleFunc("GET /repos/SwissDataScienceCenter/renku/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]string{"tag_name": "2.5.0"})
+	})
+	mux.HandleFunc("GET /orgs/SwissDataScienceCenter/packages", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "container", r.URL.Query().Get("package_type"))
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/orgs/SwissDataScienceCenter/packages?package_type=container&page=2>; rel="next"`, r.Host))
+			writeJSON(t, w, []any{map[string]string{"name": "renku/py-basic-jupyterlab"}, map[string]string{"name": "renku-ui"}})
+			return
+		}
+		writeJSON(t, w, []any{map[string]string{"name": "renku/py-datascience-ttyd"}})
+	})
+
+	api := newTestGitHubAPI(t, mux)
+	api.runGit = func(ctx context.Context, arg ...string) ([]byte, error) {
+		switch arg[0] {
+		case "remote":
+			return []byte("git@github.com:SwissDataScienceCenter/renku-ui.git\n"), nil
+		case "rev-parse":
+			return []byte("feat-branch\n"), nil
+		}
+		return nil, fmt.Errorf("unexpected git command %v", arg)
+	}
+	return api
+}
+
+func TestGitHubAPIGetPullRequestState(t *testing.T) {
+	api := newFakeGitHub(t)
+
+	for pr, expected := range map[int]string{1: "OPEN", 2: "MERGED", 3: "CLOSED"} {
+		state, err := api.GetPullRequestState(t.Context(), "SwissDataScienceCenter/renku-ui", pr)
+		require.NoError(t, err)
+		assert.Equal(t, expected, state, "pull request %d", pr)
+	}
+
+	_, err := api.GetPullRequestState(t.Context(), "SwissDataScienceCenter/renku-ui", 4)
+	assert.ErrorContains(t, err, "Not Found")
+}
+
+func TestGitHubAPIGetPullRequest(t *testing.T) {
+	api := newFakeGitHub(t)
+
+	pullRequest, err := api.GetPullRequest(t.Context(), "SwissDataScienceCenter/renku-ui", 1)
+	require.NoError(t, err)
+	assert.Equal(t, PullRequest{
+		Repository: "SwissDataScienceCenter/renku-ui",
+		Number:     1,
+		Title:      "Add the session launcher",
+		Author:     "alice",
+		State:      "OPEN",
+		HeadBranch: "feat-branch",
+		HeadSHA:    "aaa",
+		Body:       "/deploy renku-data-services=#5",
+	}, pullRequest)
+
+	user, err := api.GetCurrentUser(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user)
+}
+
+func TestGitHubAPIGetCurrentPullRequest(t *testing.T) {
+	api := newFakeGitHub(t)
+
+	repository, err := api.GetCurrentRepository(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "SwissDataScienceCenter/renku-ui", repository)
+
+	pr, err := api.GetCurrentPullRequest(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, pr)
+
+	// The branch is pushed to a fork
+	api.runGit = func(ctx context.Context, arg ...string) ([]byte, error) {
+		switch strings.Join(arg, " ") {
+		case "remote get-url origin":
+			return []byte("git@github.com:SwissDataScienceCenter/renku-ui.git\n"), nil
+		case "rev-parse --abbrev-ref HEAD":
+			return []byte("local-branch\n"), nil
+		case "rev-parse --abbrev-ref --symbolic-full-name @{push}":
+			return []byte("fork/fork-branch\n"), nil
+		case "remote get-url --push fork":
+			return []byte("https://github.com/alice/renku-ui.git\n"), nil
+		}
+		return nil, fmt.Errorf("unexpected git command %v", arg)
+	}
+	pr, err = api.GetCurrentPullRequest(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 2, pr)
+}
+
+func TestGitHubAPIListClosedPullRequests(t *testing.T) {
+	api := newFakeGitHub(t)
+
+	numbers, err := api.ListClosedPullRequests(t.Context(), "SwissDataScienceCenter/renku-ui", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 2}, numbers)
+
+	numbers, err = api.ListClosedPullRequests(t.Context(), "SwissDataScienceCenter/renku-ui", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3}, numbers)
+}
+
+func TestGitHubAPIGetLatestRenkuRelease(t *testing.T) {
+	api := newFakeGitHub(t)
+
+	release, err := api.GetLatestRenkuRelease(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "2.5.0", release)
+
+	api.token = "wrong-token"
+	_, err = api.GetLatestRenkuRelease(t.Context())
+	assert.ErrorContains(t, err, "Bad credentials")
+}
+
+func TestGitHubAPIListContainerPackages(t *testing.T) {
+	api := newFakeGitHub(t)
+
+	packages, err := api.ListContainerPackages(t.Context(), "SwissDataScienceCenter", "renku/py-")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"renku/py-basic-jupyterlab", "renku/py-datascience-ttyd"}, packages)
+}
+
+func TestRemoteRepositoryRegex(t *testing.T) {
+	t.Parallel()
+	for _, remote := range []string{
+		"git@github.com:SwissDataScienceCenter/renku-ui.git",
+		"https://github.com/SwissDataScienceCenter/renku-ui.git",
+		"https://github.com/SwissDataScienceCenter/renku-ui",
+		"ssh://git@github.com/SwissDataScienceCenter/renku-ui.git",
+	} {
+		match := remoteRepositoryRegex.FindStringSubmatch(remote)
+		require.NotNil(t, match, remote)
+		assert.Equal(t, "SwissDataScienceCenter/renku-ui", match[1], remote)
+	}
+}
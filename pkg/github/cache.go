@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// PullRequestCache keeps pull requests on disk for a short time
+type PullRequestCache struct {
+	path string
+	ttl  time.Duration
+
+	mutex   sync.Mutex
+	entries map[string]pullRequestCacheEntry
+}
+
+type pullRequestCacheEntry struct {
+	PullRequest PullRequest `json:"pullRequest"`
+	FetchedAt   time.Time   `json:"fetchedAt"`
+}
+
+// LoadPullRequestCache reads the cache at path. A missing or unreadable cache file gives an empty cache.
+func LoadPullRequestCache(path string, ttl time.Duration) *PullRequestCache {
+	cache := &PullRequestCache{path: path, ttl: ttl, entries: map[string]pullRequestCacheEntry{}}
+	if path == "" {
+		return cache
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cache
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not read the pull request cache: %s\n", err.Error())
+		return cache
+	}
+	err = json.Unmarshal(content, &cache.entries)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring the invalid pull request cache %s: %s\n", path, err.Error())
+		cache.entries = map[string]pullRequestCacheEntry{}
+	}
+	return cache
+}
+
+// GetPullRequest returns the pull request from the cache if it is fresh, and from GitHub otherwise
+func (c *PullRequestCache) GetPullRequest(ctx context.Context, client GitHubClient, repository string, pr int) (pullRequest PullRequest, err error) {
+	pullRequest, found := c.get(repository, pr)
+	if found {
+		return pullRequest, nil
+	}
+	pullRequest, err = client.GetPullRequest(ctx, repository, pr)
+	if err != nil {
+		return pullRequest, err
+	}
+	c.put(pullRequest)
+	return pullRequest, nil
+}
+
+func (c *PullRequestCache) get(repository string, pr int) (pullRequest PullRequest, found bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, found := c.entries[pullRequestCacheKey(repository, pr)]
+	if !found || time.Since(entry.FetchedAt) > c.ttl {
+		return pullRequest, false
+	}
+	return entry.PullRequest, true
+}
+
+func (c *PullRequestCache) put(pullRequest PullRequest) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[pullRequestCacheKey(pullRequest.Repository, pullRequest.Number)] = pullRequestCacheEntry{
+		PullRequest: pullRequest,
+		FetchedAt:   time.Now(),
+	}
+}
+
+// Save writes the fresh entries of the cache to disk
+func (c *PullRequestCache) Save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	fresh := map[string]pullRequestCacheEntry{}
+	for key, entry := range c.entries {
+		if time.Since(entry.FetchedAt) <= c.ttl {
+			fresh[key] = entry
+		}
+	}
+	content, err := json.Marshal(fresh)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(c.path), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, content, 0o644)
+}
+
+func pullRequestCacheKey(repository string, pr int) string {
+	return fmt.Sprintf("%s#%d", repository, pr)
+}
@@ -0,0 +1,52 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pullsClient serves a pull request and counts the calls, or fails with err
+type pullsClient struct {
+	GitHubClient
+	calls int
+	err   error
+}
+
+func (c *pullsClient) GetPullRequest(ctx context.Context, repository string, pr int) (PullRequest, error) {
+	c.calls++
+	if c.err != nil {
+		return PullRequest{}, c.err
+	}
+	return PullRequest{Repository: repository, Number: pr, Title: "Add the session launcher", Author: "alice", State: "OPEN"}, nil
+}
+
+func TestPullRequestCache(t *testing.T) {
+	client := &pullsClient{}
+	path := filepath.Join(t.TempDir(), "cache", "pull-requests.json")
+
+	cache := LoadPullRequestCache(path, time.Minute)
+	pullRequest, err := cache.GetPullRequest(t.Context(), client, "SwissDataScienceCenter/renku-ui", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Add the session launcher", pullRequest.Title)
+	require.NoError(t, cache.Save())
+
+	// The cached pull request is served without calling GitHub
+	client.err = fmt.Errorf("Bad credentials")
+	cache = LoadPullRequestCache(path, time.Minute)
+	pullRequest, err = cache.GetPullRequest(t.Context(), client, "SwissDataScienceCenter/renku-ui", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", pullRequest.Author)
+	assert.Equal(t, 1, client.calls)
+
+	// Expired entries are fetched again
+	cache = LoadPullRequestCache(path, 0)
+	_, err = cache.GetPullRequest(t.Context(), client, "SwissDataScienceCenter/renku-ui", 1)
+	assert.ErrorContains(t, err, "Bad credentials")
+	assert.Equal(t, 2, client.calls)
+}
@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Check run conclusions which make a commit fail
+var failedCheckConclusions = []string{"failure", "timed_out", "cancelled", "action_required", "startup_failure"}
+
+func (cli *GitHubCLI) GetCheckStatus(ctx context.Context, repository string, ref string) (status string, err error) {
+	endpoint := fmt.Sprintf("repos/%s/commits/%s/check-runs?per_page=100", repository, url.PathEscape(ref))
+	out, err := cli.RunCmd(ctx, "api", "--paginate", endpoint, "--jq", ".check_runs[] | {status, conclusion}")
+	if err != nil {
+		return "", err
+	}
+
+	runs := []gitHubCheckRun{}
+	decoder := json.NewDecoder(strings.NewReader(string(out)))
+	for decoder.More() {
+		var run gitHubCheckRun
+		err = decoder.Decode(&run)
+		if err != nil {
+			return "", err
+		}
+		runs = append(runs, run)
+	}
+	return summarizeCheckRuns(runs), nil
+}
+
+type gitHubCheckRuns struct {
+	CheckRuns []gitHubCheckRun `json:"check_runs"`
+}
+
+type gitHubCheckRun struct {
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+// summarizeCheckRuns returns failure if a run failed, pending if a run is not completed and success otherwise
+func summarizeCheckRuns(runs []gitHubCheckRun) string {
+	if len(runs) == 0 {
+		return "none"
+	}
+	pending := false
+	for _, run := range runs {
+		if slices.Contains(failedCheckConclusions, run.Conclusion) {
+			return "failure"
+		}
+		if run.Status != "completed" {
+			pending = true
+		}
+	}
+	if pending {
+		return "pending"
+	}
+	return "success"
+}
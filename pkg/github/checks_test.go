@@ -0,0 +1,28 @@
+package github
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubAPIGetCheckStatus(t *testing.T) {
+	checkRuns := map[string][]gitHubCheckRun{
+		"aaa": {{Status: "completed", Conclusion: "success"}, {Status: "completed", Conclusion: "skipped"}},
+		"bbb": {{Status: "completed", Conclusion: "success"}, {Status: "in_progress"}},
+		"ccc": {{Status: "in_progress"}, {Status: "completed", Conclusion: "failure"}},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/SwissDataScienceCenter/renku-ui/commits/{ref}/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, gitHubCheckRuns{CheckRuns: checkRuns[r.PathValue("ref")]})
+	})
+	api := newTestGitHubAPI(t, mux)
+
+	for ref, expected := range map[string]string{"aaa": "success", "bbb": "pending", "ccc": "failure", "ddd": "none"} {
+		status, err := api.GetCheckStatus(t.Context(), "SwissDataScienceCenter/renku-ui", ref)
+		require.NoError(t, err)
+		assert.Equal(t, expected, status, ref)
+	}
+}
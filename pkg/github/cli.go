@@ -3,11 +3,13 @@ package github
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/executils"
 )
 
+// GitHubCLI queries GitHub with the gh binary, it is used when no GitHub token is available
 type GitHubCLI struct {
 	gh string
 }
@@ -22,8 +24,7 @@ func NewGitHubCLI(gh string) (*GitHubCLI, error) {
 		return nil, err
 	}
 
-	fmt.Printf("Found GitHub CLI: %s", path)
-	fmt.Println()
+	fmt.Fprintf(os.Stderr, "Found GitHub CLI: %s\n", path)
 	return &GitHubCLI{gh: gh}, nil
 }
 
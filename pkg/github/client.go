@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/executils"
+)
+
+// GitHubClient queries GitHub about repositories, pull requests, releases and packages
+type GitHubClient interface {
+	// GetCurrentRepository returns the "owner/name" of the repository of the working directory
+	GetCurrentRepository(ctx context.Context) (string, error)
+	// GetCurrentPullRequest returns the number of the pull request of the current branch
+	GetCurrentPullRequest(ctx context.Context) (int, error)
+	// GetPullRequest returns the title, author, state, head and description of a pull request
+	GetPullRequest(ctx context.Context, repository string, pr int) (pullRequest PullRequest, err error)
+	// GetPullRequestState returns OPEN, CLOSED or MERGED
+	GetPullRequestState(ctx context.Context, repository string, pr int) (state string, err error)
+	// ListClosedPullRequests returns the numbers of the last limit closed or merged pull requests
+	ListClosedPullRequests(ctx context.Context, repository string, limit int) (numbers []int, err error)
+	// GetLatestRenkuRelease returns the tag of the latest renku release
+	GetLatestRenkuRelease(ctx context.Context) (string, error)
+	// GetCurrentUser returns the login of the authenticated user
+	GetCurrentUser(ctx context.Context) (string, error)
+	// GetCheckStatus summarizes the check runs of a commit as success, failure, pending or none
+	GetCheckStatus(ctx context.Context, repository string, ref string) (status string, err error)
+	// ListIssueComments lists the comments of an issue or a pull request
+	ListIssueComments(ctx context.Context, repository string, number int) (comments []IssueComment, err error)
+	// CreateIssueComment comments on an issue or a pull request
+	CreateIssueComment(ctx context.Context, repository string, number int, body string) error
+	// UpdateIssueComment replaces the body of a comment
+	UpdateIssueComment(ctx context.Context, repository string, commentID int64, body string) error
+	// DispatchWorkflow triggers a workflow_dispatch event of a workflow on ref
+	DispatchWorkflow(ctx context.Context, repository string, workflow string, ref string, inputs map[string]string) error
+	// ListWorkflowRuns lists the recent runs of a workflow, or of all workflows if workflow is empty, newest first
+	ListWorkflowRuns(ctx context.Context, repository string, workflow string, event string, branch string) (runs []WorkflowRun, err error)
+	// GetWorkflowRun returns a workflow run
+	GetWorkflowRun(ctx context.Context, repository string, runID int64) (run WorkflowRun, err error)
+	// ListContainerPackages lists the container packages of an organization whose name starts with prefix
+	ListContainerPackages(ctx context.Context, org string, prefix string) (packages []string, err error)
+}
+
+// PullRequest describes a pull request
+type PullRequest struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	Author     string `json:"author"`
+	// OPEN, CLOSED or MERGED
+	State      string `json:"state"`
+	HeadBranch string `json:"headBranch"`
+	HeadSHA    string `json:"headSha"`
+	Body       string `json:"body,omitempty"`
+}
+
+// IssueComment is a comment on an issue or a pull request
+type IssueComment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// WorkflowRun is a run of a GitHub Actions workflow
+type WorkflowRun struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Event string `json:"event"`
+	// queued, in_progress or completed
+	Status string `json:"status"`
+	// success, failure, cancelled, skipped... once completed
+	Conclusion string    `json:"conclusion"`
+	URL        string    `json:"html_url"`
+	CreatedAt  time.Time `json:"created_at"`
+	// Title of the run, the title of the pull request for runs triggered by a comment
+	DisplayTitle string `json:"display_title"`
+	// Pull requests of the head branch, empty for runs triggered by a comment
+	PullRequests []WorkflowRunPullRequest `json:"pull_requests"`
+}
+
+// WorkflowRunPullRequest is a pull request of a workflow run
+type WorkflowRunPullRequest struct {
+	Number int `json:"number"`
+}
+
+// NewGitHubClient returns a client of the GitHub API if a token is available,
+// from GH_TOKEN, GITHUB_TOKEN or "gh auth token", and a client using the gh binary otherwise.
+// The API client switches to the gh binary if the API rejects the token.
+func NewGitHubClient(ctx context.Context) (GitHubClient, error) {
+	token := findToken(ctx)
+	if token != "" {
+		api, err := NewGitHubAPI("", token)
+		if err != nil {
+			return nil, err
+		}
+		return newFallbackClient(api), nil
+	}
+	return NewGitHubCLI("")
+}
+
+func findToken(ctx context.Context) string {
+	for _, key := range []string{"GH_TOKEN", "GITHUB_TOKEN"} {
+		token := os.Getenv(key)
+		if token != "" {
+			return token
+		}
+	}
+
+	gh, err := exec.LookPath("gh")
+	if err != nil {
+		return ""
+	}
+	cmd := exec.CommandContext(ctx, gh, "auth", "token")
+	out, err := executils.FormatOutput(cmd.Output())
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,56 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func (cli *GitHubCLI) ListIssueComments(ctx context.Context, repository string, number int) (comments []IssueComment, err error) {
+	endpoint := fmt.Sprintf("repos/%s/issues/%d/comments?per_page=100", repository, number)
+	out, err := cli.RunCmd(ctx, "api", "--paginate", endpoint, "--jq", ".[] | {id, body}")
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(out)))
+	for decoder.More() {
+		var comment IssueComment
+		err = decoder.Decode(&comment)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, nil
+}
+
+func (cli *GitHubCLI) CreateIssueComment(ctx context.Context, repository string, number int, body string) error {
+	endpoint := fmt.Sprintf("repos/%s/issues/%d/comments", repository, number)
+	_, err := cli.RunCmd(ctx, "api", "--method", "POST", endpoint, "--raw-field", fmt.Sprintf("body=%s", body))
+	return err
+}
+
+func (cli *GitHubCLI) UpdateIssueComment(ctx context.Context, repository string, commentID int64, body string) error {
+	endpoint := fmt.Sprintf("repos/%s/issues/comments/%d", repository, commentID)
+	_, err := cli.RunCmd(ctx, "api", "--method", "PATCH", endpoint, "--raw-field", fmt.Sprintf("body=%s", body))
+	return err
+}
+
+// UpsertStickyComment updates the comment containing marker, or creates it if there is none
+func UpsertStickyComment(ctx context.Context, client GitHubClient, repository string, number int, marker string, body string) (created bool, err error) {
+	comments, err := client.ListIssueComments(ctx, repository, number)
+	if err != nil {
+		return false, err
+	}
+	if !strings.Contains(body, marker) {
+		body = fmt.Sprintf("%s\n%s", marker, body)
+	}
+	for _, comment := range comments {
+		if strings.Contains(comment.Body, marker) {
+			return false, client.UpdateIssueComment(ctx, repository, comment.ID, body)
+		}
+	}
+	return true, client.CreateIssueComment(ctx, repository, number, body)
+}
@@ -0,0 +1,61 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeCommentsGitHub returns a client of a fake GitHub API serving the comments of pull request 1
+func newFakeCommentsGitHub(t *testing.T, comments []IssueComment) *GitHubAPI {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/SwissDataScienceCenter/renku-ui/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, comments)
+	})
+	mux.HandleFunc("POST /repos/SwissDataScienceCenter/renku-ui/issues/1/comments", func(w http.ResponseWriter, r *http.Request) {
+		var body gitHubCommentBody
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		comment := IssueComment{ID: int64(10 + len(comments)), Body: body.Body}
+		comments = append(comments, comment)
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(t, w, comment)
+	})
+	mux.HandleFunc("PATCH /repos/SwissDataScienceCenter/renku-ui/issues/comments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var body gitHubCommentBody
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		for i := range comments {
+			if fmt.Sprint(comments[i].ID) == r.PathValue("id") {
+				comments[i].Body = body.Body
+				writeJSON(t, w, comments[i])
+				return
+			}
+		}
+		writeNotFound(t, w)
+	})
+	return newTestGitHubAPI(t, mux)
+}
+
+func TestUpsertStickyComment(t *testing.T) {
+	api := newFakeCommentsGitHub(t, []IssueComment{{ID: 10, Body: "LGTM"}})
+	marker := "<!-- rdu-test -->"
+
+	created, err := UpsertStickyComment(t.Context(), api, "SwissDataScienceCenter/renku-ui", 1, marker, "first")
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	created, err = UpsertStickyComment(t.Context(), api, "SwissDataScienceCenter/renku-ui", 1, marker, "second")
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	comments, err := api.ListIssueComments(t.Context(), "SwissDataScienceCenter/renku-ui", 1)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "LGTM", comments[0].Body)
+	assert.Equal(t, marker+"\nsecond", comments[1].Body)
+}
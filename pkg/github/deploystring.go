@@ -0,0 +1,79 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// The components which can be set in a deploy string
+var deployComponents = []string{
+	"amalthea",
+	"amalthea-sessions",
+	"renku",
+	"renku-data-services",
+	"renku-gateway",
+	"renku-notebooks",
+	"renku-search",
+	"renku-ui",
+}
+
+// A component version is a pull request ("#123"), a branch, a tag or a commit
+var deployVersionRegex = regexp.MustCompile(`^(#[0-9]+|[A-Za-z0-9][A-Za-z0-9._/-]*)$`)
+
+// DeployString is the "/deploy" command read by the Renku CI
+type DeployString struct {
+	// "component=version" pairs, in order
+	Components []string
+	// "key=value" pairs passed to helm as extra values
+	ExtraValues []string
+}
+
+// GetDeployComponents returns the components which can be set in a deploy string
+func GetDeployComponents() []string {
+	return slices.Clone(deployComponents)
+}
+
+// NewDeployString validates "component=version" and "key=value" pairs and returns the matching deploy string
+func NewDeployString(components []string, extraValues []string) (deploy DeployString, err error) {
+	seen := map[string]bool{}
+	for _, component := range components {
+		name, version, found := strings.Cut(component, "=")
+		if !found || version == "" {
+			return deploy, fmt.Errorf("invalid component '%s', expected 'component=version'", component)
+		}
+		if !slices.Contains(deployComponents, name) {
+			return deploy, fmt.Errorf("unknown component '%s', expected one of: %s", name, strings.Join(deployComponents, ", "))
+		}
+		if seen[name] {
+			return deploy, fmt.Errorf("component '%s' is set more than once", name)
+		}
+		if !deployVersionRegex.MatchString(version) {
+			return deploy, fmt.Errorf("invalid version '%s' for component '%s', expected '#<pr>', a branch, a tag or a commit", version, name)
+		}
+		seen[name] = true
+		deploy.Components = append(deploy.Components, fmt.Sprintf("%s=%s", name, version))
+	}
+
+	for _, value := range extraValues {
+		key, _, found := strings.Cut(value, "=")
+		if !found || key == "" {
+			return deploy, fmt.Errorf("invalid extra value '%s', expected 'key=value'", value)
+		}
+		if strings.ContainsAny(value, ", \t\n") {
+			return deploy, fmt.Errorf("invalid extra value '%s', it must not contain commas or whitespace", value)
+		}
+		deploy.ExtraValues = append(deploy.ExtraValues, value)
+	}
+
+	return deploy, nil
+}
+
+func (d DeployString) String() string {
+	parts := append([]string{"/deploy"}, d.Components...)
+	if len(d.ExtraValues) > 0 {
+		parts = append(parts, fmt.Sprintf("extra-values=%s", strings.Join(d.ExtraValues, ",")))
+	}
+	return strings.Join(parts, " ")
+}
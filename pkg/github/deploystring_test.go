@@ -0,0 +1,45 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeployString(t *testing.T) {
+	deploy, err := NewDeployString(
+		[]string{"renku-data-services=#123", "renku-ui=feat/new-launcher"},
+		[]string{"global.anonymousSessions.enabled=true", "notebooks.sessionIngress.host=example.org"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "/deploy renku-data-services=#123 renku-ui=feat/new-launcher extra-values=global.anonymousSessions.enabled=true,notebooks.sessionIngress.host=example.org", deploy.String())
+
+	deploy, err = NewDeployString(nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/deploy", deploy.String())
+}
+
+func TestNewDeployStringErrors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		components  []string
+		extraValues []string
+	}{
+		{name: "missing version", components: []string{"renku-ui"}},
+		{name: "unknown component", components: []string{"renku-foo=#1"}},
+		{name: "duplicate component", components: []string{"renku-ui=#1", "renku-ui=#2"}},
+		{name: "invalid version", components: []string{"renku-ui=# 1"}},
+		{name: "missing value", extraValues: []string{"global.debug"}},
+		{name: "comma in value", extraValues: []string{"global.hosts=a,b"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewDeployString(test.components, test.extraValues)
+			assert.Error(t, err)
+		})
+	}
+}
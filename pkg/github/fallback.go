@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// fallbackClient uses the GitHub API and switches to the gh binary once the API rejects the token,
+// e.g. when it lacks a scope or was not authorized for the organization
+type fallbackClient struct {
+	api *GitHubAPI
+	// returns the client of the gh binary
+	newFallback func() (GitHubClient, error)
+
+	mutex    sync.Mutex
+	fallback GitHubClient
+}
+
+func newFallbackClient(api *GitHubAPI) *fallbackClient {
+	return &fallbackClient{
+		api: api,
+		newFallback: func() (GitHubClient, error) {
+			return NewGitHubCLI("")
+		},
+	}
+}
+
+// getClient returns the gh client if the API already rejected the token, and the API client otherwise
+func (c *fallbackClient) getClient() GitHubClient {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.fallback != nil {
+		return c.fallback
+	}
+	return c.api
+}
+
+// getFallback returns the gh client to retry a call which failed with err, or nil if the call should not be retried
+func (c *fallbackClient) getFallback(err error) GitHubClient {
+	if !isHTTPStatus(err, http.StatusUnauthorized, http.StatusForbidden) {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.fallback == nil {
+		fallback, fallbackErr := c.newFallback()
+		if fallbackErr != nil {
+			return nil
+		}
+		fmt.Fprintf(os.Stderr, "Warning, the GitHub API rejected the token, using the GitHub CLI: %s\n", err.Error())
+		c.fallback = fallback
+	}
+	return c.fallback
+}
+
+// withFallback calls fn with the current client and retries it with the gh client if the API rejected the token
+func withFallback[T any](c *fallbackClient, fn func(client GitHubClient) (T, error)) (T, error) {
+	client := c.getClient()
+	res, err := fn(client)
+	if client != c.api {
+		return res, err
+	}
+	if fallback := c.getFallback(err); fallback != nil {
+		return fn(fallback)
+	}
+	return res, err
+}
+
+func (c *fallbackClient) GetCurrentRepository(ctx context.Context) (string, error) {
+	return withFallback(c, func(client GitHubClient) (string, error) {
+		return client.GetCurrentRepository(ctx)
+	})
+}
+
+func (c *fallbackClient) GetCurrentPullRequest(ctx context.Context) (int, error) {
+	return withFallback(c, func(client GitHubClient) (int, error) {
+		return client.GetCurrentPullRequest(ctx)
+	})
+}
+
+func (c *fallbackClient) GetPullRequest(ctx context.Context, repository string, pr int) (PullRequest, error) {
+	return withFallback(c, func(client GitHubClient) (PullRequest, error) {
+		return client.GetPullRequest(ctx, repository, pr)
+	})
+}
+
+func (c *fallbackClient) GetPullRequestState(ctx context.Context, repository string, pr int) (string, error) {
+	return withFallback(c, func(client GitHubClient) (string, error) {
+		return client.GetPullRequestState(ctx, repository, pr)
+	})
+}
+
+func (c *fallbackClient) ListClosedPullRequests(ctx context.Context, repository string, limit int) ([]int, error) {
+	return withFallback(c, func(client GitHubClient) ([]int, error) {
+		return client.ListClosedPullRequests(ctx, repository, limit)
+	})
+}
+
+func (c *fallbackClient) GetLatestRenkuRelease(ctx context.Context) (string, error) {
+	return withFallback(c, func(client GitHubClient) (string, error) {
+		return client.GetLatestRenkuRelease(ctx)
+	})
+}
+
+func (c *fallbackClient) GetCurrentUser(ctx context.Context) (string, error) {
+	return withFallback(c, func(client GitHubClient) (string, error) {
+		return client.GetCurrentUser(ctx)
+	})
+}
+
+func (c *fallbackClient) GetCheckStatus(ctx context.Context, repository string, ref string) (string, error) {
+	return withFallback(c, func(client GitHubClient) (string, error) {
+		return client.GetCheckStatus(ctx, repository, ref)
+	})
+}
+
+func (c *fallbackClient) ListIssueComments(ctx context.Context, repository string, number int) ([]IssueComment, error) {
+	return withFallback(c, func(client GitHubClient) ([]IssueComment, error) {
+		return client.ListIssueComments(ctx, repository, number)
+	})
+}
+
+func (c *fallbackClient) CreateIssueComment(ctx context.Context, repository string, number int, body string) error {
+	_, err := withFallback(c, func(client GitHubClient) (struct{}, error) {
+		return struct{}{}, client.CreateIssueComment(ctx, repository, number, body)
+	})
+	return err
+}
+
+func (c *fallbackClient) UpdateIssueComment(ctx context.Context, repository string, commentID int64, body string) error {
+	_, err := withFallback(c, func(client GitHubClient) (struct{}, error) {
+		return struct{}{}, client.UpdateIssueComment(ctx, repository, commentID, body)
+	})
+	return err
+}
+
+func (c *fallbackClient) DispatchWorkflow(ctx context.Context, repository string, workflow string, ref string, inputs map[string]string) error {
+	_, err := withFallback(c, func(client GitHubClient) (struct{}, error) {
+		return struct{}{}, client.DispatchWorkflow(ctx, repository, workflow, ref, inputs)
+	})
+	return err
+}
+
+func (c *fallbackClient) ListWorkflowRuns(ctx context.Context, repository string, workflow string, event string, branch string) ([]WorkflowRun, error) {
+	return withFallback(c, func(client GitHubClient) ([]WorkflowRun, error) {
+		return client.ListWorkflowRuns(ctx, repository, workflow, event, branch)
+	})
+}
+
+func (c *fallbackClient) GetWorkflowRun(ctx context.Context, repository string, runID int64) (WorkflowRun, error) {
+	return withFallback(c, func(client GitHubClient) (WorkflowRun, error) {
+		return client.GetWorkflowRun(ctx, repository, runID)
+	})
+}
+
+func (c *fallbackClient) ListContainerPackages(ctx context.Context, org string, prefix string) ([]string, error) {
+	return withFallback(c, func(client GitHubClient) ([]string, error) {
+		return client.ListContainerPackages(ctx, org, prefix)
+	})
+}
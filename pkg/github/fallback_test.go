@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stateClient returns the same state for every pull request
+type stateClient struct {
+	GitHubClient
+	state string
+}
+
+func (c stateClient) GetPullRequestState(ctx context.Context, repository string, pr int) (string, error) {
+	return c.state, nil
+}
+
+func TestFallbackClient(t *testing.T) {
+	api := newTestGitHubAPI(t, http.NewServeMux())
+	rejected, err := NewGitHubAPI(api.baseURL, "wrong-token")
+	require.NoError(t, err)
+
+	client := newFallbackClient(rejected)
+	client.newFallback = func() (GitHubClient, error) {
+		return stateClient{state: "OPEN"}, nil
+	}
+	state, err := client.GetPullRequestState(t.Context(), "SwissDataScienceCenter/renku-ui", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "OPEN", state)
+	assert.Equal(t, GitHubClient(stateClient{state: "OPEN"}), client.getClient())
+
+	// Other errors are not retried
+	client = newFallbackClient(api)
+	client.newFallback = func() (GitHubClient, error) {
+		return stateClient{state: "OPEN"}, nil
+	}
+	_, err = client.GetPullRequestState(t.Context(), "SwissDataScienceCenter/renku-ui", 1)
+	assert.ErrorContains(t, err, "HTTP 404")
+
+	client = newFallbackClient(rejected)
+	client.newFallback = func() (GitHubClient, error) {
+		return nil, fmt.Errorf("gh not found")
+	}
+	_, err = client.GetPullRequestState(t.Context(), "SwissDataScienceCenter/renku-ui", 1)
+	assert.ErrorContains(t, err, "HTTP 401")
+}
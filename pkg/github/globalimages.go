@@ -0,0 +1,136 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/oci"
+	"github.com/distribution/reference"
+)
+
+// The prefix of the Renku global images
+const DefaultGlobalImagesPrefix string = "ghcr.io/swissdatasciencecenter/renku/py-"
+
+// The Renku global images, used as a last resort when neither the packages of ghcr.io nor its catalog can be listed,
+// e.g. with a token which does not have the read:packages scope
+var defaultGlobalImages = []string{
+	"ghcr.io/swissdatasciencecenter/renku/py-basic-jupyterlab",
+	"ghcr.io/swissdatasciencecenter/renku/py-basic-ttyd",
+	"ghcr.io/swissdatasciencecenter/renku/py-basic-vscodium",
+	"ghcr.io/swissdatasciencecenter/renku/py-datascience-jupyterlab",
+	"ghcr.io/swissdatasciencecenter/renku/py-datascience-ttyd",
+	"ghcr.io/swissdatasciencecenter/renku/py-datascience-vscodium",
+}
+
+// DiscoverGlobalImages returns the images starting with prefix which have the given tag.
+// Images on ghcr.io are listed with the GitHub packages API, other registries with the catalog API.
+func DiscoverGlobalImages(ctx context.Context, cli GitHubClient, rc *oci.RegistryClient, prefix string, tag string) (images []string, err error) {
+	candidates, err := listImages(ctx, cli, rc, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, image := range candidates {
+		named, err := reference.ParseDockerRef(fmt.Sprintf("%s:%s", image, tag))
+		if err != nil {
+			return nil, err
+		}
+		res, err := rc.CheckImage(ctx, named)
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			fmt.Fprintf(os.Stderr, "Skipping %s: no tag %s\n", image, tag)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+
+	if len(images) == 0 {
+		return nil, fmt.Errorf("could not find any image starting with %s with tag %s", prefix, tag)
+	}
+	slices.Sort(images)
+	return images, nil
+}
+
+// repositoryLister lists the repositories of a registry, like oci.RegistryClient
+type repositoryLister interface {
+	ListRepositories(ctx context.Context, domain string, prefix string) (repositories []string, err error)
+}
+
+func listImages(ctx context.Context, cli GitHubClient, rc repositoryLister, prefix string) (images []string, err error) {
+	domain, pathPrefix, found := strings.Cut(prefix, "/")
+	if !found || pathPrefix == "" {
+		return nil, fmt.Errorf("invalid image prefix '%s', expected registry/path", prefix)
+	}
+
+	if domain == "ghcr.io" {
+		if cli == nil {
+			return nil, fmt.Errorf("a GitHub client is required to list the images of ghcr.io")
+		}
+		org, packagePrefix, _ := strings.Cut(pathPrefix, "/")
+		packages, err := cli.ListContainerPackages(ctx, org, packagePrefix)
+		if isHTTPStatus(err, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound) {
+			return listFallbackImages(ctx, rc, prefix, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range packages {
+			images = append(images, strings.ToLower(fmt.Sprintf("%s/%s/%s", domain, org, name)))
+		}
+		return images, nil
+	}
+
+	return listCatalogImages(ctx, rc, domain, pathPrefix)
+}
+
+func listCatalogImages(ctx context.Context, rc repositoryLister, domain string, pathPrefix string) (images []string, err error) {
+	repositories, err := rc.ListRepositories(ctx, domain, pathPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for _, repository := range repositories {
+		images = append(images, fmt.Sprintf("%s/%s", domain, repository))
+	}
+	return images, nil
+}
+
+// listFallbackImages lists the images of ghcr.io when its packages cannot be listed:
+// from the catalog of the registry, and then from the built-in global images
+func listFallbackImages(ctx context.Context, rc repositoryLister, prefix string, packagesErr error) (images []string, err error) {
+	domain, pathPrefix, _ := strings.Cut(prefix, "/")
+	catalogErr := fmt.Errorf("no registry client")
+	if rc != nil {
+		images, catalogErr = listCatalogImages(ctx, rc, domain, pathPrefix)
+		if catalogErr == nil && len(images) > 0 {
+			fmt.Fprintf(os.Stderr, "Warning, could not list the packages of ghcr.io, using its catalog: %s\n", packagesErr.Error())
+			return images, nil
+		}
+	}
+
+	images = getDefaultGlobalImages(prefix)
+	if len(images) == 0 {
+		return nil, packagesErr
+	}
+	fmt.Fprintln(os.Stderr, "WARNING: could not discover the images of ghcr.io, using the BUILT-IN list of global images, which may be outdated.")
+	fmt.Fprintf(os.Stderr, "  packages: %s\n", packagesErr.Error())
+	if catalogErr != nil {
+		fmt.Fprintf(os.Stderr, "  catalog: %s\n", catalogErr.Error())
+	}
+	fmt.Fprintln(os.Stderr, "  Grant the read:packages scope to your GitHub token to discover the images, e.g. with \"gh auth refresh --scopes read:packages\".")
+	return images, nil
+}
+
+func getDefaultGlobalImages(prefix string) (images []string) {
+	for _, image := range defaultGlobalImages {
+		if strings.HasPrefix(image, strings.ToLower(prefix)) {
+			images = append(images, image)
+		}
+	}
+	return images
+}
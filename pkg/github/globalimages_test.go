@@ -0,0 +1,105 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// packagesClient lists the packages of a GitHub organization, or fails with err
+type packagesClient struct {
+	GitHubClient
+	packages []string
+	err      error
+}
+
+func (c packagesClient) ListContainerPackages(ctx context.Context, org string, prefix string) ([]string, error) {
+	return c.packages, c.err
+}
+
+// fakeCatalog lists fixed repositories, or fails with err
+type fakeCatalog struct {
+	repositories []string
+	err          error
+}
+
+func (c fakeCatalog) ListRepositories(ctx context.Context, domain string, prefix string) ([]string, error) {
+	return c.repositories, c.err
+}
+
+func TestListImages(t *testing.T) {
+	t.Parallel()
+	forbidden := &APIError{Path: "/orgs/swissdatasciencecenter/packages", StatusCode: 403, Message: "You need at least read:packages scope to list packages."}
+	tests := []struct {
+		name    string
+		cli     GitHubClient
+		catalog repositoryLister
+		prefix  string
+		out     []string
+		err     string
+	}{
+		{
+			name:   "packages",
+			cli:    packagesClient{packages: []string{"renku/py-basic-jupyterlab", "renku/py-R-vscodium"}},
+			prefix: DefaultGlobalImagesPrefix,
+			out:    []string{"ghcr.io/swissdatasciencecenter/renku/py-basic-jupyterlab", "ghcr.io/swissdatasciencecenter/renku/py-r-vscodium"},
+		},
+		{
+			name:    "catalog when the packages cannot be listed",
+			cli:     packagesClient{err: forbidden},
+			catalog: fakeCatalog{repositories: []string{"swissdatasciencecenter/renku/py-basic-jupyterlab"}},
+			prefix:  DefaultGlobalImagesPrefix,
+			out:     []string{"ghcr.io/swissdatasciencecenter/renku/py-basic-jupyterlab"},
+		},
+		{
+			name:    "built-in images when the catalog cannot be listed",
+			cli:     packagesClient{err: forbidden},
+			catalog: fakeCatalog{err: fmt.Errorf("could not authenticate with registry at ghcr.io")},
+			prefix:  DefaultGlobalImagesPrefix,
+			out:     defaultGlobalImages,
+		},
+		{
+			name:    "built-in images matching the prefix",
+			cli:     packagesClient{err: forbidden},
+			catalog: fakeCatalog{},
+			prefix:  "ghcr.io/swissdatasciencecenter/renku/py-basic-",
+			out:     defaultGlobalImages[:3],
+		},
+		{
+			name:    "no built-in image matching the prefix",
+			cli:     packagesClient{err: forbidden},
+			catalog: fakeCatalog{},
+			prefix:  "ghcr.io/swissdatasciencecenter/other-",
+			err:     "HTTP 403",
+		},
+		{
+			name:    "other errors are not ignored",
+			cli:     packagesClient{err: &APIError{Path: "/orgs/swissdatasciencecenter/packages", StatusCode: 500}},
+			catalog: fakeCatalog{repositories: []string{"swissdatasciencecenter/renku/py-basic-jupyterlab"}},
+			prefix:  DefaultGlobalImagesPrefix,
+			err:     "HTTP 500",
+		},
+		{
+			name:    "other registries",
+			catalog: fakeCatalog{repositories: []string{"renku/py-basic-jupyterlab"}},
+			prefix:  "registry.renku.ch/renku/py-",
+			out:     []string{"registry.renku.ch/renku/py-basic-jupyterlab"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			images, err := listImages(t.Context(), test.cli, test.catalog, test.prefix)
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.out, images)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The owner of the Renku repositories
+const renkuOwner string = "SwissDataScienceCenter"
+
+// Components of a deploy string which do not have a repository of their own
+var deployComponentRepositories = map[string]string{
+	"amalthea-sessions": "amalthea",
+}
+
+var (
+	pullRequestURLRegex = regexp.MustCompile(`https://github\.com/([\w.-]+/[\w.-]+)/pull/([0-9]+)`)
+	pullRequestRefRegex = regexp.MustCompile(`(?:^|[\s(\[])([\w.-]+/[\w.-]+)#([0-9]+)\b`)
+	deployLineRegex     = regexp.MustCompile(`(?m)^\s*/deploy\b(.*)$`)
+)
+
+// PullRequestRef identifies a pull request
+type PullRequestRef struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+}
+
+func (ref PullRequestRef) String() string {
+	return fmt.Sprintf("%s#%d", ref.Repository, ref.Number)
+}
+
+// ParseLinkedPullRequests returns the pull requests referenced in the description of a pull request, in order of appearance.
+// References are read from the "/deploy" command ("renku-ui=#123"), from pull request URLs and from "owner/repo#123".
+func ParseLinkedPullRequests(body string) (refs []PullRequestRef) {
+	add := func(repository string, number string) {
+		n, err := strconv.Atoi(number)
+		if err != nil || n <= 0 {
+			return
+		}
+		ref := PullRequestRef{Repository: repository, Number: n}
+		if !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, line := range deployLineRegex.FindAllStringSubmatch(body, -1) {
+		for _, field := range strings.Fields(line[1]) {
+			name, version, found := strings.Cut(field, "=")
+			if !found || !slices.Contains(deployComponents, name) || !strings.HasPrefix(version, "#") {
+				continue
+			}
+			if repository, found := deployComponentRepositories[name]; found {
+				name = repository
+			}
+			add(fmt.Sprintf("%s/%s", renkuOwner, name), strings.TrimPrefix(version, "#"))
+		}
+	}
+
+	for _, match := range pullRequestURLRegex.FindAllStringSubmatch(body, -1) {
+		add(match[1], match[2])
+	}
+	for _, match := range pullRequestRefRegex.FindAllStringSubmatch(body, -1) {
+		add(match[1], match[2])
+	}
+
+	return refs
+}
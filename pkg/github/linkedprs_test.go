@@ -0,0 +1,26 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLinkedPullRequests(t *testing.T) {
+	body := `Combined deployment of the new launcher.
+
+/deploy renku-data-services=#123 renku-ui=#456 renku-gateway=main amalthea-sessions=#7 extra-values=a=b
+
+Depends on https://github.com/SwissDataScienceCenter/renku-ui/pull/456 and SwissDataScienceCenter/renku-search#12.
+See also (SwissDataScienceCenter/renku-data-services#123).`
+
+	refs := ParseLinkedPullRequests(body)
+	assert.Equal(t, []PullRequestRef{
+		{Repository: "SwissDataScienceCenter/renku-data-services", Number: 123},
+		{Repository: "SwissDataScienceCenter/renku-ui", Number: 456},
+		{Repository: "SwissDataScienceCenter/amalthea", Number: 7},
+		{Repository: "SwissDataScienceCenter/renku-search", Number: 12},
+	}, refs)
+
+	assert.Empty(t, ParseLinkedPullRequests("No linked pull requests, see #12"))
+}
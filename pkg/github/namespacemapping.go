@@ -0,0 +1,126 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// The placeholder for the pull request number in namespace templates
+const prPlaceholder string = "{pr}"
+
+// NamespaceMapping maps a repository to the namespace template of its pull request deployments
+type NamespaceMapping struct {
+	// e.g. SwissDataScienceCenter/renku-ui
+	Repository string `json:"repo"`
+	// e.g. renku-ci-ui-{pr}
+	Namespace string `json:"namespace"`
+}
+
+// namespaceMappingsConfig is the format of the namespace mappings config file
+type namespaceMappingsConfig struct {
+	Namespaces []NamespaceMapping `json:"namespaces"`
+}
+
+var defaultNamespaceMappings = []NamespaceMapping{
+	{Repository: "SwissDataScienceCenter/amalthea", Namespace: "renku-ci-am-{pr}"},
+	{Repository: "SwissDataScienceCenter/renku", Namespace: "ci-renku-{pr}"},
+	{Repository: "SwissDataScienceCenter/renku-data-services", Namespace: "renku-ci-ds-{pr}"},
+	{Repository: "SwissDataScienceCenter/renku-ui", Namespace: "renku-ci-ui-{pr}"},
+	{Repository: "SwissDataScienceCenter/renku-gateway", Namespace: "renku-ci-gw-{pr}"},
+}
+
+type namespaceMapping struct {
+	repository string
+	template   string
+	regex      *regexp.Regexp
+}
+
+// GetNamespaceMappings returns the repository to namespace mappings in use
+func GetNamespaceMappings() []NamespaceMapping {
+	mappings := make([]NamespaceMapping, 0, len(namespaceMappings))
+	for _, mapping := range namespaceMappings {
+		mappings = append(mappings, NamespaceMapping{Repository: mapping.repository, Namespace: mapping.template})
+	}
+	return mappings
+}
+
+// LoadNamespaceMappings adds the mappings of a config file to the built-in ones.
+// A mapping from the config file replaces the built-in mapping of the same repository.
+// A missing config file, or an empty path, is not an error.
+//
+// The config file looks like:
+//
+//	namespaces:
+//	  - repo: SwissDataScienceCenter/renku-ui
+//	    namespace: renku-ci-ui-{pr}
+func LoadNamespaceMappings(path string) error {
+	if path == "" {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var config namespaceMappingsConfig
+	err = yaml.UnmarshalStrict(content, &config)
+	if err != nil {
+		return fmt.Errorf("could not parse %s: %w", path, err)
+	}
+
+	merged := []NamespaceMapping{}
+	for _, mapping := range defaultNamespaceMappings {
+		overridden := false
+		for _, configured := range config.Namespaces {
+			if configured.Repository == mapping.Repository {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			merged = append(merged, mapping)
+		}
+	}
+	merged = append(merged, config.Namespaces...)
+
+	mappings, err := compileNamespaceMappings(merged)
+	if err != nil {
+		return fmt.Errorf("invalid namespace mapping in %s: %w", path, err)
+	}
+	namespaceMappings = mappings
+	return nil
+}
+
+func compileNamespaceMappings(mappings []NamespaceMapping) (compiled []namespaceMapping, err error) {
+	for _, mapping := range mappings {
+		regex, err := namespaceTemplateToRegex(mapping.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if mapping.Repository == "" {
+			return nil, fmt.Errorf("missing repository for namespace template '%s'", mapping.Namespace)
+		}
+		compiled = append(compiled, namespaceMapping{
+			repository: mapping.Repository,
+			template:   mapping.Namespace,
+			regex:      regex,
+		})
+	}
+	return compiled, nil
+}
+
+// namespaceTemplateToRegex turns "renku-ci-ui-{pr}" into `^renku-ci-ui-(\d+)$`
+func namespaceTemplateToRegex(template string) (*regexp.Regexp, error) {
+	if strings.Count(template, prPlaceholder) != 1 {
+		return nil, fmt.Errorf("namespace template '%s' must contain %s exactly once", template, prPlaceholder)
+	}
+	prefix, suffix, _ := strings.Cut(template, prPlaceholder)
+	return regexp.Compile(fmt.Sprintf(`^%s(\d+)%s$`, regexp.QuoteMeta(prefix), regexp.QuoteMeta(suffix)))
+}
@@ -0,0 +1,61 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceTemplateToRegex(t *testing.T) {
+	t.Parallel()
+
+	regex, err := namespaceTemplateToRegex("renku-ci-ui-{pr}")
+	require.NoError(t, err)
+	assert.Equal(t, `^renku-ci-ui-(\d+)$`, regex.String())
+
+	regex, err = namespaceTemplateToRegex("ci.{pr}.renku")
+	require.NoError(t, err)
+	assert.True(t, regex.MatchString("ci.42.renku"))
+	assert.False(t, regex.MatchString("cix42xrenku"))
+
+	_, err = namespaceTemplateToRegex("renku-ci-ui")
+	assert.Error(t, err)
+	_, err = namespaceTemplateToRegex("renku-{pr}-{pr}")
+	assert.Error(t, err)
+}
+
+func TestLoadNamespaceMappings(t *testing.T) {
+	t.Cleanup(initNamespaceMappings)
+
+	path := filepath.Join(t.TempDir(), "namespaces.yaml")
+	config := `namespaces:
+  - repo: SwissDataScienceCenter/renku-search
+    namespace: renku-ci-search-{pr}
+  - repo: SwissDataScienceCenter/renku-ui
+    namespace: renku-ci-frontend-{pr}
+`
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+	require.NoError(t, LoadNamespaceMappings(path))
+
+	namespace, err := DeriveK8sNamespace("SwissDataScienceCenter/renku-search", 12)
+	require.NoError(t, err)
+	assert.Equal(t, "renku-ci-search-12", namespace)
+	repository, pr := MatchDeploymentNamespace("renku-ci-search-12")
+	assert.Equal(t, "SwissDataScienceCenter/renku-search", repository)
+	assert.Equal(t, 12, pr)
+
+	namespace, err = DeriveK8sNamespace("SwissDataScienceCenter/renku-ui", 7)
+	require.NoError(t, err)
+	assert.Equal(t, "renku-ci-frontend-7", namespace)
+	repository, _ = MatchDeploymentNamespace("renku-ci-ui-7")
+	assert.Equal(t, "", repository)
+
+	namespace, err = DeriveK8sNamespace("SwissDataScienceCenter/renku-data-services", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "renku-ci-ds-3", namespace)
+
+	require.NoError(t, LoadNamespaceMappings(filepath.Join(t.TempDir(), "missing.yaml")))
+}
@@ -0,0 +1,24 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ListContainerPackages lists the container packages of an organization whose name starts with prefix
+func (cli *GitHubCLI) ListContainerPackages(ctx context.Context, org string, prefix string) (packages []string, err error) {
+	endpoint := fmt.Sprintf("/orgs/%s/packages?package_type=container&per_page=100", org)
+	out, err := cli.RunCmd(ctx, "api", "--paginate", endpoint, "--jq", ".[].name")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range strings.Split(string(out), "\n") {
+		name = strings.TrimSpace(name)
+		if name != "" && strings.HasPrefix(name, prefix) {
+			packages = append(packages, name)
+		}
+	}
+	return packages, nil
+}
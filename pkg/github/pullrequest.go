@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 func (cli *GitHubCLI) GetCurrentPullRequest(ctx context.Context) (int, error) {
@@ -43,3 +44,65 @@ func (cli *GitHubCLI) GetPullRequestState(ctx context.Context, repository string
 type gitHubPRViewStateOutput struct {
 	State string `json:"state"`
 }
+
+func (cli *GitHubCLI) ListClosedPullRequests(ctx context.Context, repository string, limit int) (numbers []int, err error) {
+	out, err := cli.RunCmd(ctx, "pr", "list", "--repo", repository, "--state", "closed", "--limit", fmt.Sprintf("%d", limit), "--json", "number")
+	if err != nil {
+		return nil, err
+	}
+
+	var res []gitHubPRViewOutput
+	err = json.Unmarshal(out, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pull := range res {
+		numbers = append(numbers, pull.Number)
+	}
+	return numbers, nil
+}
+
+func (cli *GitHubCLI) GetPullRequest(ctx context.Context, repository string, pr int) (pullRequest PullRequest, err error) {
+	out, err := cli.RunCmd(ctx, "pr", "view", "--repo", repository, "--json", "number,title,author,state,headRefName,headRefOid,body", fmt.Sprintf("%d", pr))
+	if err != nil {
+		return pullRequest, err
+	}
+
+	var res gitHubPRViewDetailsOutput
+	err = json.Unmarshal(out, &res)
+	if err != nil {
+		return pullRequest, err
+	}
+
+	return PullRequest{
+		Repository: repository,
+		Number:     res.Number,
+		Title:      res.Title,
+		Author:     res.Author.Login,
+		State:      res.State,
+		HeadBranch: res.HeadRefName,
+		HeadSHA:    res.HeadRefOid,
+		Body:       res.Body,
+	}, nil
+}
+
+type gitHubPRViewDetailsOutput struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	State       string `json:"state"`
+	HeadRefName string `json:"headRefName"`
+	HeadRefOid  string `json:"headRefOid"`
+	Body        string `json:"body"`
+}
+
+func (cli *GitHubCLI) GetCurrentUser(ctx context.Context) (string, error) {
+	out, err := cli.RunCmd(ctx, "api", "user", "--jq", ".login")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
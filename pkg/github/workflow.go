@@ -0,0 +1,140 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+func (cli *GitHubCLI) DispatchWorkflow(ctx context.Context, repository string, workflow string, ref string, inputs map[string]string) error {
+	endpoint := fmt.Sprintf("repos/%s/actions/workflows/%s/dispatches", repository, url.PathEscape(workflow))
+	args := []string{"api", "--method", "POST", endpoint, "--raw-field", fmt.Sprintf("ref=%s", ref)}
+	for key, value := range inputs {
+		args = append(args, "--raw-field", fmt.Sprintf("inputs[%s]=%s", key, value))
+	}
+	_, err := cli.RunCmd(ctx, args...)
+	return err
+}
+
+func (cli *GitHubCLI) ListWorkflowRuns(ctx context.Context, repository string, workflow string, event string, branch string) (runs []WorkflowRun, err error) {
+	out, err := cli.RunCmd(ctx, "api", getWorkflowRunsPath(repository, workflow, event, branch))
+	if err != nil {
+		return nil, err
+	}
+
+	var res gitHubWorkflowRuns
+	err = json.Unmarshal(out, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.WorkflowRuns, nil
+}
+
+func (cli *GitHubCLI) GetWorkflowRun(ctx context.Context, repository string, runID int64) (run WorkflowRun, err error) {
+	out, err := cli.RunCmd(ctx, "api", fmt.Sprintf("repos/%s/actions/runs/%d", repository, runID))
+	if err != nil {
+		return run, err
+	}
+
+	err = json.Unmarshal(out, &run)
+	return run, err
+}
+
+type gitHubWorkflowRuns struct {
+	WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+}
+
+func getWorkflowRunsPath(repository string, workflow string, event string, branch string) string {
+	path := fmt.Sprintf("/repos/%s/actions/runs", repository)
+	if workflow != "" {
+		path = fmt.Sprintf("/repos/%s/actions/workflows/%s/runs", repository, url.PathEscape(workflow))
+	}
+	query := url.Values{"per_page": {"30"}}
+	if event != "" {
+		query.Set("event", event)
+	}
+	if branch != "" {
+		query.Set("branch", branch)
+	}
+	return fmt.Sprintf("%s?%s", path, query.Encode())
+}
+
+// MatchPullRequestRun returns a filter accepting the workflow runs of a pull request.
+// Runs triggered by a comment do not list their pull request, they are matched by their title instead.
+func MatchPullRequestRun(pullRequest PullRequest) func(run WorkflowRun) bool {
+	return func(run WorkflowRun) bool {
+		for _, pull := range run.PullRequests {
+			if pull.Number == pullRequest.Number {
+				return true
+			}
+		}
+		return run.DisplayTitle != "" && run.DisplayTitle == pullRequest.Title
+	}
+}
+
+// WaitForWorkflowRun waits for the first run created after since which is not skipped and accepted by match, if not nil,
+// and polls it until it completes.
+// progress is called when a run is found and every time its status changes.
+func WaitForWorkflowRun(ctx context.Context, client GitHubClient, repository string, workflow string, event string, branch string, since time.Time, interval time.Duration, match func(run WorkflowRun) bool, progress func(run WorkflowRun)) (run WorkflowRun, err error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	skipped := map[int64]bool{}
+	for {
+		if run.ID == 0 {
+			run, err = findWorkflowRun(ctx, client, repository, workflow, event, branch, since, match, skipped)
+			if err == nil && run.ID != 0 {
+				progress(run)
+			}
+		} else {
+			previous := run.Status
+			run, err = client.GetWorkflowRun(ctx, repository, run.ID)
+			if err == nil && run.Status != previous {
+				progress(run)
+			}
+		}
+		if err != nil {
+			return run, err
+		}
+
+		if run.ID != 0 && run.Status == "completed" {
+			if run.Conclusion != "skipped" {
+				return run, nil
+			}
+			// Workflows triggered by the same event can skip their jobs, look for another run
+			skipped[run.ID] = true
+			run = WorkflowRun{}
+		}
+
+		select {
+		case <-ctx.Done():
+			return run, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func findWorkflowRun(ctx context.Context, client GitHubClient, repository string, workflow string, event string, branch string, since time.Time, match func(run WorkflowRun) bool, skipped map[int64]bool) (run WorkflowRun, err error) {
+	runs, err := client.ListWorkflowRuns(ctx, repository, workflow, event, branch)
+	if err != nil {
+		return run, err
+	}
+
+	// Runs are listed newest first, keep the oldest one created after since
+	for _, candidate := range runs {
+		if candidate.CreatedAt.Before(since) || skipped[candidate.ID] {
+			continue
+		}
+		if match != nil && !match(candidate) {
+			continue
+		}
+		if candidate.Status == "completed" && candidate.Conclusion == "skipped" {
+			skipped[candidate.ID] = true
+			continue
+		}
+		run = candidate
+	}
+	return run, nil
+}
@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeWorkflowGitHub returns a client of a fake GitHub API where dispatching deploy.yml starts a run,
+// which moves forward every time it is polled
+func newFakeWorkflowGitHub(t *testing.T) *GitHubAPI {
+	t.Helper()
+
+	runs := []WorkflowRun{{ID: 100, Name: "Deploy", Event: "workflow_dispatch", Status: "completed", Conclusion: "success", CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}}
+	runPolls := map[int64]int{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /repos/SwissDataScienceCenter/renku-ui/actions/workflows/deploy.yml/dispatches", func(w http.ResponseWriter, r *http.Request) {
+		var body gitHubWorkflowDispatch
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "feat-branch", body.Ref)
+		now := time.Now().UTC()
+		runs = append([]WorkflowRun{
+			{ID: 102, Name: "Deploy", Event: "workflow_dispatch", Status: "queued", CreatedAt: now},
+			{ID: 101, Name: "Lint", Event: "workflow_dispatch", Status: "completed", Conclusion: "skipped", CreatedAt: now},
+		}, runs...)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET /repos/SwissDataScienceCenter/renku-ui/actions/runs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "workflow_dispatch", r.URL.Query().Get("event"))
+		writeJSON(t, w, gitHubWorkflowRuns{WorkflowRuns: runs})
+	})
+	mux.HandleFunc("GET /repos/SwissDataScienceCenter/renku-ui/actions/runs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		for i := range runs {
+			if fmt.Sprint(runs[i].ID) != r.PathValue("id") {
+				continue
+			}
+			runPolls[runs[i].ID]++
+			switch runPolls[runs[i].ID] {
+			case 1:
+				runs[i].Status = "in_progress"
+			default:
+				runs[i].Status = "completed"
+				runs[i].Conclusion = "success"
+			}
+			writeJSON(t, w, runs[i])
+			return
+		}
+		writeNotFound(t, w)
+	})
+	return newTestGitHubAPI(t, mux)
+}
+
+func TestWaitForWorkflowRun(t *testing.T) {
+	api := newFakeWorkflowGitHub(t)
+	repository := "SwissDataScienceCenter/renku-ui"
+
+	since := time.Now().Add(-time.Minute)
+	err := api.DispatchWorkflow(t.Context(), repository, "deploy.yml", "feat-branch", map[string]string{"deploy": "/deploy"})
+	require.NoError(t, err)
+
+	statuses := []string{}
+	run, err := WaitForWorkflowRun(t.Context(), api, repository, "", "workflow_dispatch", "", since, time.Millisecond, nil, func(run WorkflowRun) {
+		statuses = append(statuses, run.Status)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(102), run.ID)
+	assert.Equal(t, "success", run.Conclusion)
+	assert.Equal(t, []string{"queued", "in_progress", "completed"}, statuses)
+}
+
+// runsClient lists fixed workflow runs
+type runsClient struct {
+	GitHubClient
+	runs []WorkflowRun
+}
+
+func (c runsClient) ListWorkflowRuns(ctx context.Context, repository string, workflow string, event string, branch string) ([]WorkflowRun, error) {
+	return c.runs, nil
+}
+
+func TestFindWorkflowRunOfPullRequest(t *testing.T) {
+	since := time.Now().Add(-time.Minute)
+	client := runsClient{runs: []WorkflowRun{
+		{ID: 3, Event: "issue_comment", DisplayTitle: "Other pull request", CreatedAt: time.Now()},
+		{ID: 2, Event: "issue_comment", DisplayTitle: "Add a feature", CreatedAt: time.Now()},
+		{ID: 1, Event: "issue_comment", DisplayTitle: "Add a feature", CreatedAt: since.Add(-time.Minute)},
+	}}
+	pullRequest := PullRequest{Repository: "SwissDataScienceCenter/renku-ui", Number: 1, Title: "Add a feature"}
+
+	run, err := findWorkflowRun(t.Context(), client, pullRequest.Repository, "", "issue_comment", "", since, MatchPullRequestRun(pullRequest), map[int64]bool{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), run.ID)
+
+	client.runs = []WorkflowRun{
+		{ID: 5, Event: "pull_request", PullRequests: []WorkflowRunPullRequest{{Number: 1}}, CreatedAt: time.Now()},
+		{ID: 4, Event: "pull_request", PullRequests: []WorkflowRunPullRequest{{Number: 2}}, CreatedAt: time.Now()},
+	}
+	run, err = findWorkflowRun(t.Context(), client, pullRequest.Repository, "", "", "", since, MatchPullRequestRun(pullRequest), map[int64]bool{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), run.ID)
+
+	client.runs = client.runs[1:]
+	run, err = findWorkflowRun(t.Context(), client, pullRequest.Repository, "", "", "", since, MatchPullRequestRun(pullRequest), map[int64]bool{})
+	require.NoError(t, err)
+	assert.Zero(t, run.ID)
+}
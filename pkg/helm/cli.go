@@ -3,11 +3,13 @@ package helm
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/executils"
 )
 
+// HelmCLI manages releases with the helm binary
 type HelmCLI struct {
 	helm string
 }
@@ -22,8 +24,7 @@ func NewHelmCLI(helm string) (cli *HelmCLI, err error) {
 		return nil, err
 	}
 
-	fmt.Printf("Found Helm CLI: %s", path)
-	fmt.Println()
+	fmt.Fprintf(os.Stderr, "Found Helm CLI: %s\n", path)
 	return &HelmCLI{helm: helm}, nil
 }
 
@@ -0,0 +1,55 @@
+package helm
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ValueDiff is a value which differs between two sets of values.
+// Old or New is nil when the value is only set on one side.
+type ValueDiff struct {
+	Path string `json:"path"`
+	Old  any    `json:"old"`
+	New  any    `json:"new"`
+}
+
+// DiffValues compares two sets of values leaf by leaf, lists are compared as a whole
+func DiffValues(oldValues map[string]any, newValues map[string]any) (diffs []ValueDiff) {
+	oldLeaves := map[string]any{}
+	flattenValues(oldValues, "", oldLeaves)
+	newLeaves := map[string]any{}
+	flattenValues(newValues, "", newLeaves)
+
+	paths := slices.Collect(maps.Keys(oldLeaves))
+	for path := range newLeaves {
+		if _, found := oldLeaves[path]; !found {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		oldValue := oldLeaves[path]
+		newValue := newLeaves[path]
+		if !reflect.DeepEqual(oldValue, newValue) {
+			diffs = append(diffs, ValueDiff{Path: path, Old: oldValue, New: newValue})
+		}
+	}
+	return diffs
+}
+
+func flattenValues(values map[string]any, prefix string, leaves map[string]any) {
+	for key, value := range values {
+		path := key
+		if prefix != "" {
+			path = strings.Join([]string{prefix, key}, ".")
+		}
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			flattenValues(nested, path, leaves)
+			continue
+		}
+		leaves[path] = value
+	}
+}
@@ -0,0 +1,29 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffValues(t *testing.T) {
+	oldValues := map[string]any{
+		"global": map[string]any{"renku": map[string]any{"domain": "renku-ci-ds-1.dev.renku.ch"}},
+		"ui":     map[string]any{"client": map[string]any{"image": map[string]any{"tag": "3.0.0"}}},
+		"hosts":  []any{"a", "b"},
+		"debug":  true,
+	}
+	newValues := map[string]any{
+		"global": map[string]any{"renku": map[string]any{"domain": "renku-ci-ds-1.dev.renku.ch"}},
+		"ui":     map[string]any{"client": map[string]any{"image": map[string]any{"tag": "3.1.0"}}},
+		"hosts":  []any{"a", "b"},
+		"search": map[string]any{"enabled": false},
+	}
+
+	diffs := DiffValues(oldValues, newValues)
+	assert.Equal(t, []ValueDiff{
+		{Path: "debug", Old: true, New: nil},
+		{Path: "search.enabled", Old: nil, New: false},
+		{Path: "ui.client.image.tag", Old: "3.0.0", New: "3.1.0"},
+	}, diffs)
+}
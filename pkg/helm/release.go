@@ -4,9 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/yaml"
 )
 
-func (cli *HelmCLI) ListReleases(ctx context.Context, namespace string) (releases []string, err error) {
+// Time layout used by "helm list" for the "updated" field
+const helmListTimeLayout string = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+func (cli *HelmCLI) ListReleases(ctx context.Context, namespace string) (releases []Release, err error) {
 	out, err := cli.RunCmd(ctx, "list", "--namespace", namespace, "--all", "--output", "json")
 	if err != nil {
 		return nil, err
@@ -19,17 +27,35 @@ func (cli *HelmCLI) ListReleases(ctx context.Context, namespace string) (release
 	}
 
 	for _, release := range res {
-		releases = append(releases, release.Name)
+		revision, _ := strconv.Atoi(release.Revision)
+		updated, _ := time.Parse(helmListTimeLayout, release.Updated)
+		chart, chartVersion := splitChart(release.Chart)
+		releases = append(releases, Release{
+			Name:         release.Name,
+			Namespace:    release.Namespace,
+			Revision:     revision,
+			Updated:      updated,
+			Status:       release.Status,
+			Chart:        chart,
+			ChartVersion: chartVersion,
+			AppVersion:   release.AppVersion,
+		})
 	}
 	return releases, nil
 }
 
 type helmListOutput struct {
-	Name string
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Revision   string `json:"revision"`
+	Updated    string `json:"updated"`
+	Status     string `json:"status"`
+	Chart      string `json:"chart"`
+	AppVersion string `json:"app_version"`
 }
 
-func (cli *HelmCLI) UninstallReleases(ctx context.Context, namespace string, releases []string) error {
-	args := []string{"uninstall", "--wait", "--namespace", namespace}
+func (cli *HelmCLI) UninstallReleases(ctx context.Context, namespace string, releases []string, timeout time.Duration) error {
+	args := []string{"uninstall", "--wait", "--timeout", timeout.String(), "--namespace", namespace}
 	args = append(args, releases...)
 
 	out, err := cli.RunCmd(ctx, args...)
@@ -42,13 +68,165 @@ func (cli *HelmCLI) UninstallReleases(ctx context.Context, namespace string, rel
 	return nil
 }
 
-func (cli *HelmCLI) UninstallAllReleases(ctx context.Context, namespace string) error {
-	releases, err := cli.ListReleases(ctx, namespace)
+func (cli *HelmCLI) GetHistory(ctx context.Context, namespace string, release string) (history []Release, err error) {
+	out, err := cli.RunCmd(ctx, "history", release, "--namespace", namespace, "--output", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	var res []helmHistoryOutput
+	err = json.Unmarshal(out, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, revision := range res {
+		chart, chartVersion := splitChart(revision.Chart)
+		history = append(history, Release{
+			Name:         release,
+			Namespace:    namespace,
+			Revision:     revision.Revision,
+			Updated:      revision.Updated,
+			Status:       revision.Status,
+			Chart:        chart,
+			ChartVersion: chartVersion,
+			AppVersion:   revision.AppVersion,
+			Description:  revision.Description,
+		})
+	}
+	return history, nil
+}
+
+func (cli *HelmCLI) Rollback(ctx context.Context, namespace string, release string, revision int, wait bool, timeout time.Duration) error {
+	args := []string{"rollback", release}
+	if revision > 0 {
+		args = append(args, strconv.Itoa(revision))
+	}
+	args = append(args, "--namespace", namespace, "--timeout", timeout.String())
+	if wait {
+		args = append(args, "--wait")
+	}
+
+	out, err := cli.RunCmd(ctx, args...)
 	if err != nil {
 		return err
 	}
-	if len(releases) == 0 {
-		return nil
+
+	fmt.Println(string(out))
+
+	return nil
+}
+
+func (cli *HelmCLI) GetValues(ctx context.Context, namespace string, release string, all bool) (values map[string]any, err error) {
+	args := []string{"get", "values", release, "--namespace", namespace, "--output", "json"}
+	if all {
+		args = append(args, "--all")
+	}
+	out, err := cli.RunCmd(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(out, &values)
+	if err != nil {
+		return nil, err
 	}
-	return cli.UninstallReleases(ctx, namespace, releases)
+	if values == nil {
+		values = map[string]any{}
+	}
+	return values, nil
+}
+
+type helmHistoryOutput struct {
+	Revision    int       `json:"revision"`
+	Updated     time.Time `json:"updated"`
+	Status      string    `json:"status"`
+	Chart       string    `json:"chart"`
+	AppVersion  string    `json:"app_version"`
+	Description string    `json:"description"`
+}
+
+// splitChart splits "renku-2.1.0" into "renku" and "2.1.0"
+func splitChart(chart string) (name string, version string) {
+	for i := 0; i < len(chart)-1; i++ {
+		if chart[i] == '-' && chart[i+1] >= '0' && chart[i+1] <= '9' {
+			return chart[:i], chart[i+1:]
+		}
+	}
+	return chart, ""
+}
+
+func (cli *HelmCLI) InstallOrUpgrade(ctx context.Context, namespace string, release string, chart ChartRef, values map[string]any, timeout time.Duration) (result Release, err error) {
+	valuesFile, err := os.CreateTemp("", "rdu-values-*.yaml")
+	if err != nil {
+		return result, err
+	}
+	defer func() {
+		if err := os.Remove(valuesFile.Name()); err != nil {
+			fmt.Printf("Warning, could not remove values file: %s", err.Error())
+		}
+	}()
+	valuesBytes, err := yaml.Marshal(values)
+	if err != nil {
+		return result, err
+	}
+	_, err = valuesFile.Write(valuesBytes)
+	if err != nil {
+		return result, err
+	}
+	err = valuesFile.Close()
+	if err != nil {
+		return result, err
+	}
+
+	args := []string{"upgrade", release, chart.Name, "--install", "--create-namespace", "--namespace", namespace}
+	if chart.RepoURL != "" {
+		args = append(args, "--repo", chart.RepoURL)
+	}
+	if chart.Version != "" {
+		args = append(args, "--version", chart.Version)
+	}
+	args = append(args, "--values", valuesFile.Name(), "--wait", "--timeout", timeout.String(), "--output", "json")
+
+	out, err := cli.RunCmd(ctx, args...)
+	if err != nil {
+		return result, err
+	}
+
+	var res helmReleaseOutput
+	err = json.Unmarshal(out, &res)
+	if err != nil {
+		return result, err
+	}
+
+	result = Release{
+		Name:         res.Name,
+		Namespace:    res.Namespace,
+		Revision:     res.Version,
+		Updated:      res.Info.LastDeployed,
+		Status:       res.Info.Status,
+		Chart:        res.Chart.Metadata.Name,
+		ChartVersion: res.Chart.Metadata.Version,
+		AppVersion:   res.Chart.Metadata.AppVersion,
+		Description:  res.Info.Description,
+	}
+	return result, nil
+}
+
+type helmReleaseOutput struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   int    `json:"version"`
+	Info      struct {
+		LastDeployed time.Time `json:"last_deployed"`
+		Status       string    `json:"status"`
+		Description  string    `json:"description"`
+	} `json:"info"`
+	Chart struct {
+		Metadata struct {
+			Name       string `json:"name"`
+			Version    string `json:"version"`
+			AppVersion string `json:"appVersion"`
+		} `json:"metadata"`
+	} `json:"chart"`
 }
@@ -0,0 +1,102 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ReleaseManager manages the helm releases of a namespace
+type ReleaseManager interface {
+	// ListReleases returns all the releases of a namespace, whatever their status
+	ListReleases(ctx context.Context, namespace string) (releases []Release, err error)
+	// UninstallReleases uninstalls releases and waits until their resources are deleted
+	UninstallReleases(ctx context.Context, namespace string, releases []string, timeout time.Duration) error
+	// GetHistory returns the revisions of a release, oldest first
+	GetHistory(ctx context.Context, namespace string, release string) (history []Release, err error)
+	// Rollback rolls a release back to a revision, the previous one if revision is 0.
+	// If wait is set, it waits until the resources are ready.
+	Rollback(ctx context.Context, namespace string, release string, revision int, wait bool, timeout time.Duration) error
+	// GetValues returns the user-supplied values of a release, or all the computed values if all is set
+	GetValues(ctx context.Context, namespace string, release string, all bool) (values map[string]any, err error)
+	// InstallOrUpgrade installs a release, or upgrades it if it exists, and waits until it is ready
+	InstallOrUpgrade(ctx context.Context, namespace string, release string, chart ChartRef, values map[string]any, timeout time.Duration) (result Release, err error)
+}
+
+// ChartRef locates a chart
+type ChartRef struct {
+	// chart name in the repository, local path or OCI reference
+	Name string
+	// URL of the chart repository, if any
+	RepoURL string
+	// chart version, the latest if empty
+	Version string
+}
+
+// Release describes a revision of a helm release
+type Release struct {
+	Name         string    `json:"name"`
+	Namespace    string    `json:"namespace"`
+	Revision     int       `json:"revision"`
+	Updated      time.Time `json:"updated"`
+	Status       string    `json:"status"`
+	Chart        string    `json:"chart"`
+	ChartVersion string    `json:"chartVersion"`
+	AppVersion   string    `json:"appVersion"`
+	Description  string    `json:"description"`
+}
+
+const (
+	// BackendSDK manages releases in-process with the helm SDK
+	BackendSDK string = "sdk"
+	// BackendCLI manages releases with the helm binary
+	BackendCLI string = "cli"
+)
+
+// NewReleaseManager returns a release manager for the given backend
+func NewReleaseManager(backend string) (ReleaseManager, error) {
+	switch backend {
+	case "", BackendSDK:
+		return NewHelmSDK()
+	case BackendCLI:
+		return NewHelmCLI("")
+	}
+	return nil, fmt.Errorf("unknown helm backend '%s', expected '%s' or '%s'", backend, BackendSDK, BackendCLI)
+}
+
+func UninstallAllReleases(ctx context.Context, rm ReleaseManager, namespace string, timeout time.Duration) error {
+	releases, err := rm.ListReleases(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	if len(releases) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(releases))
+	for _, release := range releases {
+		names = append(names, release.Name)
+	}
+	return rm.UninstallReleases(ctx, namespace, names, timeout)
+}
+
+// UpgradeValues upgrades a release to new values and waits until it is ready.
+// Without a chart name, the chart and version of the deployed release are used: they are located again
+// with the repository of chart, since helm does not keep the subcharts of the deployed releases.
+func UpgradeValues(ctx context.Context, rm ReleaseManager, namespace string, release string, chart ChartRef, values map[string]any, timeout time.Duration) (result Release, err error) {
+	if chart.Name == "" {
+		releases, err := rm.ListReleases(ctx, namespace)
+		if err != nil {
+			return result, err
+		}
+		for _, r := range releases {
+			if r.Name == release {
+				chart.Name, chart.Version = r.Chart, r.ChartVersion
+				break
+			}
+		}
+		if chart.Name == "" {
+			return result, fmt.Errorf("release '%s' not found in namespace '%s'", release, namespace)
+		}
+	}
+	return rm.InstallOrUpgrade(ctx, namespace, release, chart, values, timeout)
+}
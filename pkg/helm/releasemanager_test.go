@@ -0,0 +1,46 @@
+package helm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upgradeRecorder records the charts its releases are upgraded with
+type upgradeRecorder struct {
+	ReleaseManager
+	releases []Release
+	charts   []ChartRef
+}
+
+func (rm *upgradeRecorder) ListReleases(ctx context.Context, namespace string) ([]Release, error) {
+	return rm.releases, nil
+}
+
+func (rm *upgradeRecorder) InstallOrUpgrade(ctx context.Context, namespace string, release string, chart ChartRef, values map[string]any, timeout time.Duration) (Release, error) {
+	rm.charts = append(rm.charts, chart)
+	return Release{Name: release, Namespace: namespace, Chart: chart.Name, ChartVersion: chart.Version}, nil
+}
+
+func TestUpgradeValues(t *testing.T) {
+	rm := &upgradeRecorder{releases: []Release{
+		{Name: "renku-secrets", Chart: "secrets", ChartVersion: "0.1.0"},
+		{Name: "renku", Chart: "renku", ChartVersion: "2.1.0"},
+	}}
+	repoURL := "https://swissdatasciencecenter.github.io/helm-charts"
+
+	_, err := UpgradeValues(t.Context(), rm, "renku-ci-ds-1", "renku", ChartRef{RepoURL: repoURL, Version: "3.0.0"}, map[string]any{}, time.Minute)
+	require.NoError(t, err)
+	_, err = UpgradeValues(t.Context(), rm, "renku-ci-ds-1", "renku", ChartRef{Name: "./helm-chart/renku"}, map[string]any{}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []ChartRef{
+		{Name: "renku", RepoURL: repoURL, Version: "2.1.0"},
+		{Name: "./helm-chart/renku"},
+	}, rm.charts)
+
+	_, err = UpgradeValues(t.Context(), rm, "renku-ci-ds-1", "other", ChartRef{RepoURL: repoURL}, map[string]any{}, time.Minute)
+	assert.ErrorContains(t, err, "release 'other' not found")
+}
@@ -0,0 +1,245 @@
+package helm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"helm.sh/helm/v4/pkg/action"
+	ci "helm.sh/helm/v4/pkg/chart"
+	"helm.sh/helm/v4/pkg/chart/loader"
+	"helm.sh/helm/v4/pkg/cli"
+	"helm.sh/helm/v4/pkg/kube"
+	"helm.sh/helm/v4/pkg/registry"
+	ri "helm.sh/helm/v4/pkg/release"
+	rv1 "helm.sh/helm/v4/pkg/release/v1"
+	"helm.sh/helm/v4/pkg/storage/driver"
+)
+
+// HelmSDK manages releases in-process with the helm SDK
+type HelmSDK struct {
+	settings *cli.EnvSettings
+
+	// returns the action configuration for a namespace
+	getConfiguration func(namespace string) (*action.Configuration, error)
+}
+
+func NewHelmSDK() (sdk *HelmSDK, err error) {
+	sdk = &HelmSDK{
+		settings: cli.New(),
+	}
+	sdk.getConfiguration = sdk.getDefaultConfiguration
+	return sdk, nil
+}
+
+func (sdk *HelmSDK) getDefaultConfiguration(namespace string) (cfg *action.Configuration, err error) {
+	// Fresh settings for each namespace, so that namespaces can be queried concurrently
+	settings := cli.New()
+	settings.SetNamespace(namespace)
+	cfg = action.NewConfiguration()
+	err = cfg.Init(settings.RESTClientGetter(), namespace, os.Getenv("HELM_DRIVER"))
+	if err != nil {
+		return nil, err
+	}
+	registryClient, err := registry.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	cfg.RegistryClient = registryClient
+	return cfg, nil
+}
+
+func (sdk *HelmSDK) ListReleases(ctx context.Context, namespace string) (releases []Release, err error) {
+	cfg, err := sdk.getConfiguration(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	list := action.NewList(cfg)
+	list.All = true
+	list.StateMask = action.ListAll
+	res, err := list.Run()
+	if err != nil {
+		return nil, err
+	}
+	return toReleases(res)
+}
+
+func (sdk *HelmSDK) UninstallReleases(ctx context.Context, namespace string, releases []string, timeout time.Duration) error {
+	cfg, err := sdk.getConfiguration(namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, release := range releases {
+		uninstall := action.NewUninstall(cfg)
+		uninstall.WaitStrategy = kube.StatusWatcherStrategy
+		uninstall.Timeout = timeout
+		res, err := uninstall.Run(release)
+		if err != nil {
+			return err
+		}
+		if res.Info != "" {
+			fmt.Println(res.Info)
+		}
+		fmt.Printf("release \"%s\" uninstalled\n", release)
+	}
+	return nil
+}
+
+func (sdk *HelmSDK) GetHistory(ctx context.Context, namespace string, release string) (history []Release, err error) {
+	cfg, err := sdk.getConfiguration(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := action.NewHistory(cfg).Run(release)
+	if err != nil {
+		return nil, err
+	}
+	history, err = toReleases(res)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(history, func(a, b Release) int { return a.Revision - b.Revision })
+	return history, nil
+}
+
+func (sdk *HelmSDK) Rollback(ctx context.Context, namespace string, release string, revision int, wait bool, timeout time.Duration) error {
+	cfg, err := sdk.getConfiguration(namespace)
+	if err != nil {
+		return err
+	}
+
+	rollback := action.NewRollback(cfg)
+	rollback.Version = revision
+	rollback.WaitStrategy = kube.HookOnlyStrategy
+	if wait {
+		rollback.WaitStrategy = kube.StatusWatcherStrategy
+	}
+	rollback.Timeout = timeout
+	return rollback.Run(release)
+}
+
+func (sdk *HelmSDK) GetValues(ctx context.Context, namespace string, release string, all bool) (values map[string]any, err error) {
+	cfg, err := sdk.getConfiguration(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	getValues := action.NewGetValues(cfg)
+	getValues.AllValues = all
+	values, err = getValues.Run(release)
+	if err != nil {
+		return nil, err
+	}
+	if values == nil {
+		values = map[string]any{}
+	}
+	return values, nil
+}
+
+func (sdk *HelmSDK) InstallOrUpgrade(ctx context.Context, namespace string, release string, chartRef ChartRef, values map[string]any, timeout time.Duration) (result Release, err error) {
+	cfg, err := sdk.getConfiguration(namespace)
+	if err != nil {
+		return result, err
+	}
+
+	exists := true
+	history := action.NewHistory(cfg)
+	history.Max = 1
+	_, err = history.Run(release)
+	if err != nil && errors.Is(err, driver.ErrReleaseNotFound) {
+		exists = false
+	} else if err != nil {
+		return result, err
+	}
+
+	var rel ri.Releaser
+	if exists {
+		upgrade := action.NewUpgrade(cfg)
+		upgrade.Namespace = namespace
+		upgrade.RepoURL = chartRef.RepoURL
+		upgrade.Version = chartRef.Version
+		upgrade.WaitStrategy = kube.StatusWatcherStrategy
+		upgrade.Timeout = timeout
+		chrt, err := sdk.loadChart(&upgrade.ChartPathOptions, chartRef)
+		if err != nil {
+			return result, err
+		}
+		rel, err = upgrade.RunWithContext(ctx, release, chrt, values)
+		if err != nil {
+			return result, err
+		}
+	} else {
+		install := action.NewInstall(cfg)
+		install.Namespace = namespace
+		install.ReleaseName = release
+		install.CreateNamespace = true
+		install.RepoURL = chartRef.RepoURL
+		install.Version = chartRef.Version
+		install.WaitStrategy = kube.StatusWatcherStrategy
+		install.Timeout = timeout
+		chrt, err := sdk.loadChart(&install.ChartPathOptions, chartRef)
+		if err != nil {
+			return result, err
+		}
+		rel, err = install.RunWithContext(ctx, chrt, values)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	releases, err := toReleases([]ri.Releaser{rel})
+	if err != nil {
+		return result, err
+	}
+	return releases[0], nil
+}
+
+func (sdk *HelmSDK) loadChart(opts *action.ChartPathOptions, chartRef ChartRef) (chrt ci.Charter, err error) {
+	path, err := opts.LocateChart(chartRef.Name, sdk.settings)
+	if err != nil {
+		return nil, err
+	}
+	return loader.Load(path)
+}
+
+func toReleases(releasers []ri.Releaser) (releases []Release, err error) {
+	for _, releaser := range releasers {
+		rel, err := toV1Release(releaser)
+		if err != nil {
+			return nil, err
+		}
+		release := Release{
+			Name:      rel.Name,
+			Namespace: rel.Namespace,
+			Revision:  rel.Version,
+		}
+		if rel.Info != nil {
+			release.Updated = rel.Info.LastDeployed
+			release.Status = rel.Info.Status.String()
+			release.Description = rel.Info.Description
+		}
+		if rel.Chart != nil && rel.Chart.Metadata != nil {
+			release.Chart = rel.Chart.Metadata.Name
+			release.ChartVersion = rel.Chart.Metadata.Version
+			release.AppVersion = rel.Chart.Metadata.AppVersion
+		}
+		releases = append(releases, release)
+	}
+	return releases, nil
+}
+
+func toV1Release(releaser ri.Releaser) (*rv1.Release, error) {
+	switch rel := releaser.(type) {
+	case *rv1.Release:
+		return rel, nil
+	case rv1.Release:
+		return &rel, nil
+	}
+	return nil, fmt.Errorf("unsupported helm release type %T", releaser)
+}
@@ -0,0 +1,141 @@
+package helm
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v4/pkg/action"
+	chartcommon "helm.sh/helm/v4/pkg/chart/common"
+	chart "helm.sh/helm/v4/pkg/chart/v2"
+	kubefake "helm.sh/helm/v4/pkg/kube/fake"
+	"helm.sh/helm/v4/pkg/release/common"
+	rv1 "helm.sh/helm/v4/pkg/release/v1"
+	"helm.sh/helm/v4/pkg/storage"
+	"helm.sh/helm/v4/pkg/storage/driver"
+)
+
+// newFakeHelmSDK returns a HelmSDK backed by an in-memory release store
+func newFakeHelmSDK(t *testing.T, releases ...*rv1.Release) *HelmSDK {
+	t.Helper()
+
+	cfg := action.NewConfiguration()
+	cfg.Releases = storage.Init(driver.NewMemory())
+	cfg.KubeClient = &kubefake.PrintingKubeClient{Out: io.Discard}
+	cfg.Capabilities = chartcommon.DefaultCapabilities
+	for _, rel := range releases {
+		require.NoError(t, cfg.Releases.Create(rel))
+	}
+
+	return &HelmSDK{
+		getConfiguration: func(namespace string) (*action.Configuration, error) {
+			return cfg, nil
+		},
+	}
+}
+
+func newFakeRelease(name string, namespace string, revision int, status common.Status, chartVersion string) *rv1.Release {
+	return &rv1.Release{
+		Name:      name,
+		Namespace: namespace,
+		Version:   revision,
+		Info: &rv1.Info{
+			LastDeployed: time.Date(2025, 1, revision, 0, 0, 0, 0, time.UTC),
+			Status:       status,
+			Description:  "Install complete",
+		},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "renku", Version: chartVersion, AppVersion: chartVersion},
+		},
+	}
+}
+
+func TestHelmSDKListReleases(t *testing.T) {
+	sdk := newFakeHelmSDK(t,
+		newFakeRelease("renku", "renku-ci-ds-1", 1, common.StatusDeployed, "2.0.0"),
+		newFakeRelease("renku-secrets", "renku-ci-ds-1", 1, common.StatusFailed, "0.1.0"),
+	)
+
+	releases, err := sdk.ListReleases(t.Context(), "renku-ci-ds-1")
+	require.NoError(t, err)
+	require.Len(t, releases, 2)
+
+	byName := map[string]Release{}
+	for _, release := range releases {
+		byName[release.Name] = release
+	}
+	assert.Equal(t, "deployed", byName["renku"].Status)
+	assert.Equal(t, "renku", byName["renku"].Chart)
+	assert.Equal(t, "2.0.0", byName["renku"].ChartVersion)
+	assert.Equal(t, "failed", byName["renku-secrets"].Status)
+}
+
+func TestHelmSDKGetHistory(t *testing.T) {
+	sdk := newFakeHelmSDK(t,
+		newFakeRelease("renku", "renku-ci-ds-1", 2, common.StatusDeployed, "2.1.0"),
+		newFakeRelease("renku", "renku-ci-ds-1", 1, common.StatusSuperseded, "2.0.0"),
+	)
+
+	history, err := sdk.GetHistory(t.Context(), "renku-ci-ds-1", "renku")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 1, history[0].Revision)
+	assert.Equal(t, "superseded", history[0].Status)
+	assert.Equal(t, 2, history[1].Revision)
+	assert.Equal(t, "2.1.0", history[1].ChartVersion)
+}
+
+func TestUninstallAllReleases(t *testing.T) {
+	sdk := newFakeHelmSDK(t,
+		newFakeRelease("renku", "renku-ci-ds-1", 1, common.StatusDeployed, "2.0.0"),
+		newFakeRelease("renku-secrets", "renku-ci-ds-1", 1, common.StatusDeployed, "0.1.0"),
+	)
+
+	err := UninstallAllReleases(t.Context(), sdk, "renku-ci-ds-1", time.Minute)
+	require.NoError(t, err)
+
+	releases, err := sdk.ListReleases(t.Context(), "renku-ci-ds-1")
+	require.NoError(t, err)
+	assert.Empty(t, releases)
+}
+
+func TestSplitChart(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		name    string
+		version string
+	}{
+		{in: "renku-2.1.0", name: "renku", version: "2.1.0"},
+		{in: "renku-data-services-0.1.0-rc-1", name: "renku-data-services", version: "0.1.0-rc-1"},
+		{in: "renku", name: "renku", version: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			t.Parallel()
+
+			name, version := splitChart(test.in)
+			assert.Equal(t, test.name, name)
+			assert.Equal(t, test.version, version)
+		})
+	}
+}
+
+func TestHelmSDKRollback(t *testing.T) {
+	sdk := newFakeHelmSDK(t,
+		newFakeRelease("renku", "renku-ci-ds-1", 1, common.StatusSuperseded, "2.0.0"),
+		newFakeRelease("renku", "renku-ci-ds-1", 2, common.StatusDeployed, "2.1.0"),
+	)
+
+	err := sdk.Rollback(t.Context(), "renku-ci-ds-1", "renku", 0, false, time.Minute)
+	require.NoError(t, err)
+
+	history, err := sdk.GetHistory(t.Context(), "renku-ci-ds-1", "renku")
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, "deployed", history[2].Status)
+	assert.Equal(t, "2.0.0", history[2].ChartVersion)
+	assert.Equal(t, "superseded", history[1].Status)
+}
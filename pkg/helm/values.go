@@ -0,0 +1,117 @@
+package helm
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/distribution/reference"
+	"helm.sh/helm/v4/pkg/cli"
+	"helm.sh/helm/v4/pkg/cli/values"
+	"helm.sh/helm/v4/pkg/getter"
+)
+
+// Maps renku components to the path of their image in the values of the renku chart
+var componentImagePaths = map[string][]string{
+	"data-service":     {"dataService", "image"},
+	"data-tasks":       {"dataService", "dataTasks", "image"},
+	"k8s-watcher":      {"dataService", "k8sWatcher", "image"},
+	"gateway":          {"gateway", "image"},
+	"notebooks":        {"notebooks", "image"},
+	"search-api":       {"search", "searchApi", "image"},
+	"search-provision": {"search", "searchProvision", "image"},
+	"secrets-storage":  {"secretsStorage", "image"},
+	"ui":               {"ui", "client", "image"},
+	"ui-server":        {"ui", "server", "image"},
+}
+
+// GetComponents returns the names of the renku components whose image can be set
+func GetComponents() []string {
+	components := make([]string, 0, len(componentImagePaths))
+	for component := range componentImagePaths {
+		components = append(components, component)
+	}
+	slices.Sort(components)
+	return components
+}
+
+// MergeValues merges values files and "key=value" pairs like "helm --values --set" does
+func MergeValues(valueFiles []string, setValues []string) (vals map[string]any, err error) {
+	opts := values.Options{
+		ValueFiles: valueFiles,
+		Values:     setValues,
+	}
+	return opts.MergeValues(getter.All(cli.New()))
+}
+
+// SetComponentImage sets the image repository and tag of a renku component
+func SetComponentImage(vals map[string]any, component string, image string) error {
+	path, found := componentImagePaths[component]
+	if !found {
+		return fmt.Errorf("unknown component '%s', expected one of: %s", component, strings.Join(GetComponents(), ", "))
+	}
+
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return err
+	}
+	tagged, ok := named.(reference.Tagged)
+	if !ok {
+		return fmt.Errorf("image '%s' must have a tag", image)
+	}
+
+	SetValue(vals, append(slices.Clone(path), "repository"), reference.FamiliarName(named))
+	SetValue(vals, append(slices.Clone(path), "tag"), tagged.Tag())
+	return nil
+}
+
+// GetComponentImage returns the image of a renku component, if it is set in the values
+func GetComponentImage(vals map[string]any, component string) (image string, found bool) {
+	path, found := componentImagePaths[component]
+	if !found {
+		return "", false
+	}
+	repository, _ := GetValue(vals, append(slices.Clone(path), "repository")).(string)
+	if repository == "" {
+		return "", false
+	}
+	tag := fmt.Sprint(GetValue(vals, append(slices.Clone(path), "tag")))
+	if tag == "" || tag == "<nil>" {
+		return repository, true
+	}
+	return fmt.Sprintf("%s:%s", repository, tag), true
+}
+
+// SetValue sets a nested value, creating the intermediate maps as needed
+func SetValue(vals map[string]any, path []string, value any) {
+	current := vals
+	for _, key := range path[:len(path)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			current[key] = next
+		}
+		current = next
+	}
+	current[path[len(path)-1]] = value
+}
+
+// GetValue returns a nested value, or nil if it is not set
+func GetValue(vals map[string]any, path []string) any {
+	var current any = vals
+	for _, key := range path {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = m[key]
+	}
+	return current
+}
+
+// SetDefaultValue sets a nested value, unless it is already set
+func SetDefaultValue(vals map[string]any, path []string, value any) {
+	if GetValue(vals, path) == nil {
+		SetValue(vals, path, value)
+	}
+}
@@ -0,0 +1,35 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetComponentImage(t *testing.T) {
+	values := map[string]any{"ui": map[string]any{"client": map[string]any{"replicaCount": 1}}}
+
+	require.NoError(t, SetComponentImage(values, "ui", "renku/renku-ui:3.0.0"))
+	require.NoError(t, SetComponentImage(values, "data-service", "ghcr.io/swissdatasciencecenter/renku-data-service:pr-42"))
+
+	assert.Equal(t, "renku/renku-ui", GetValue(values, []string{"ui", "client", "image", "repository"}))
+	assert.Equal(t, "3.0.0", GetValue(values, []string{"ui", "client", "image", "tag"}))
+	assert.Equal(t, 1, GetValue(values, []string{"ui", "client", "replicaCount"}))
+	image, found := GetComponentImage(values, "data-service")
+	assert.True(t, found)
+	assert.Equal(t, "ghcr.io/swissdatasciencecenter/renku-data-service:pr-42", image)
+
+	assert.Error(t, SetComponentImage(values, "unknown", "renku/renku-ui:3.0.0"))
+	assert.Error(t, SetComponentImage(values, "ui", "renku/renku-ui"))
+}
+
+func TestSetDefaultValue(t *testing.T) {
+	values := map[string]any{"global": map[string]any{"renku": map[string]any{"domain": "example.org"}}}
+
+	SetDefaultValue(values, []string{"global", "renku", "domain"}, "renku-ci-ds-1.dev.renku.ch")
+	SetDefaultValue(values, []string{"ingress", "hosts"}, []any{"renku-ci-ds-1.dev.renku.ch"})
+
+	assert.Equal(t, "example.org", GetValue(values, []string{"global", "renku", "domain"}))
+	assert.Equal(t, []any{"renku-ci-ds-1.dev.renku.ch"}, GetValue(values, []string{"ingress", "hosts"}))
+}
@@ -4,20 +4,24 @@ import (
 	"context"
 	"fmt"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 )
 
+// Default AmaltheaSession resource, used when the discovery API is not available
 var amaltheaSessionGroupVersionResource = schema.GroupVersionResource{
-	Group:    "amalthea.dev",
+	Group:    amaltheaGroup,
 	Version:  "v1alpha1",
 	Resource: "amaltheasessions",
 }
 
 func ListAmaltheaSessions(ctx context.Context, client *dynamic.DynamicClient, namespace string, gvr *schema.GroupVersionResource) (sessions []string, err error) {
-	if gvr == nil {
-		gvr = &amaltheaSessionGroupVersionResource
+	gvr, err = resolveAmaltheaSessionGvr(gvr)
+	if err != nil {
+		return nil, err
 	}
 
 	ams, err := client.Resource(*gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
@@ -32,12 +36,13 @@ func ListAmaltheaSessions(ctx context.Context, client *dynamic.DynamicClient, na
 }
 
 func DeleteAmaltheaSession(ctx context.Context, client *dynamic.DynamicClient, namespace string, name string, gvr *schema.GroupVersionResource) error {
-	if gvr == nil {
-		gvr = &amaltheaSessionGroupVersionResource
+	gvr, err := resolveAmaltheaSessionGvr(gvr)
+	if err != nil {
+		return err
 	}
 
 	propagation := metav1.DeletePropagationBackground
-	err := client.Resource(*gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	err = client.Resource(*gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
 	if err != nil {
 		return err
 	}
@@ -45,21 +50,29 @@ func DeleteAmaltheaSession(ctx context.Context, client *dynamic.DynamicClient, n
 }
 
 func DeleteAmaltheaSessions(ctx context.Context, client *dynamic.DynamicClient, namespace string, gvr *schema.GroupVersionResource) error {
-	if gvr == nil {
-		gvr = &amaltheaSessionGroupVersionResource
+	gvr, err := resolveAmaltheaSessionGvr(gvr)
+	if err != nil && k8serrors.IsNotFound(err) {
+		fmt.Printf("Warning: %s\n", err)
+		return nil
+	} else if err != nil {
+		return err
 	}
 
 	propagation := metav1.DeletePropagationBackground
-	err := client.Resource(*gvr).Namespace(namespace).DeleteCollection(ctx, metav1.DeleteOptions{PropagationPolicy: &propagation}, metav1.ListOptions{})
-	if err != nil {
+	err = client.Resource(*gvr).Namespace(namespace).DeleteCollection(ctx, metav1.DeleteOptions{PropagationPolicy: &propagation}, metav1.ListOptions{})
+	if err != nil && k8serrors.IsNotFound(err) {
+		fmt.Printf("Warning: Resource not found: %s\n", gvr.String())
+		return nil
+	} else if err != nil {
 		return err
 	}
 	return nil
 }
 
 func ForciblyDeleteAmaltheaSession(ctx context.Context, client *dynamic.DynamicClient, namespace string, name string, gvr *schema.GroupVersionResource) error {
-	if gvr == nil {
-		gvr = &amaltheaSessionGroupVersionResource
+	gvr, err := resolveAmaltheaSessionGvr(gvr)
+	if err != nil {
+		return err
 	}
 
 	session, err := client.Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -80,9 +93,27 @@ func ForciblyDeleteAmaltheaSession(ctx context.Context, client *dynamic.DynamicC
 	return nil
 }
 
+// SetAmaltheaSessionHibernated hibernates or resumes an AmaltheaSession
+func SetAmaltheaSessionHibernated(ctx context.Context, client *dynamic.DynamicClient, namespace string, name string, hibernated bool, gvr *schema.GroupVersionResource) error {
+	gvr, err := resolveAmaltheaSessionGvr(gvr)
+	if err != nil {
+		return err
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"hibernated":%t}}`, hibernated)
+	_, err = client.Resource(*gvr).Namespace(namespace).Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func ForciblyDeleteAmaltheaSessions(ctx context.Context, client *dynamic.DynamicClient, namespace string, gvr *schema.GroupVersionResource) error {
 	servers, err := ListAmaltheaSessions(ctx, client, namespace, gvr)
-	if err != nil {
+	if err != nil && k8serrors.IsNotFound(err) {
+		fmt.Printf("Warning: %s\n", err)
+		return nil
+	} else if err != nil {
 		return err
 	}
 
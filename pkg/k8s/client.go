@@ -4,20 +4,16 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
 )
 
 func GetClientset() (*kubernetes.Clientset, error) {
-	home := homedir.HomeDir()
-	if home == "" {
-		return nil, fmt.Errorf("could not determine home directory")
-	}
-
-	kubeconfig := filepath.Join(home, ".kube", "config")
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	config, err := getRestConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -26,16 +22,55 @@ func GetClientset() (*kubernetes.Clientset, error) {
 }
 
 func GetDynamicClient() (client *dynamic.DynamicClient, err error) {
-	home := homedir.HomeDir()
-	if home == "" {
-		return nil, fmt.Errorf("could not determine home directory")
+	config, err := getRestConfig()
+	if err != nil {
+		return nil, err
 	}
 
-	kubeconfig := filepath.Join(home, ".kube", "config")
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	return dynamic.NewForConfig(config)
+}
+
+func GetDiscoveryClient() (client *discovery.DiscoveryClient, err error) {
+	config, err := getRestConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	return dynamic.NewForConfig(config)
+	return discovery.NewDiscoveryClientForConfig(config)
+}
+
+// GetCurrentContextNamespace returns the namespace set on the current kube context, or "" if there is none
+func GetCurrentContextNamespace() (namespace string, err error) {
+	kubeconfig, err := getKubeconfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	config, err := clientcmd.LoadFromFile(kubeconfig)
+	if err != nil {
+		return "", err
+	}
+	kubeContext, found := config.Contexts[config.CurrentContext]
+	if !found {
+		return "", nil
+	}
+	return kubeContext.Namespace, nil
+}
+
+func getRestConfig() (config *rest.Config, err error) {
+	kubeconfig, err := getKubeconfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	return clientcmd.BuildConfigFromFlags("", kubeconfig)
+}
+
+func getKubeconfigPath() (kubeconfig string, err error) {
+	home := homedir.HomeDir()
+	if home == "" {
+		return "", fmt.Errorf("could not determine home directory")
+	}
+
+	return filepath.Join(home, ".kube", "config"), nil
 }
@@ -0,0 +1,128 @@
+package k8s
+
+import (
+	"fmt"
+	"sync"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+const amaltheaGroup string = "amalthea.dev"
+
+// SessionResources holds the session custom resources served by the cluster.
+// A resource is nil when its CRD is not installed.
+type SessionResources struct {
+	AmaltheaSession *schema.GroupVersionResource
+	JupyterServer   *schema.GroupVersionResource
+}
+
+// Cached result of the session resources discovery
+var sessionResources *SessionResources
+var sessionResourcesMu sync.Mutex
+
+// DiscoverSessionResources finds which session CRDs are served by the cluster.
+// The preferred version of the API group is used when it serves the resource.
+func DiscoverSessionResources(client discovery.DiscoveryInterface) (resources SessionResources, err error) {
+	groups, err := client.ServerGroups()
+	if err != nil {
+		return resources, err
+	}
+
+	for _, group := range groups.Groups {
+		if group.Name != amaltheaGroup {
+			continue
+		}
+
+		versions := []string{group.PreferredVersion.Version}
+		for _, version := range group.Versions {
+			if version.Version != group.PreferredVersion.Version {
+				versions = append(versions, version.Version)
+			}
+		}
+
+		for _, version := range versions {
+			gv := schema.GroupVersion{Group: group.Name, Version: version}
+			resourceList, err := client.ServerResourcesForGroupVersion(gv.String())
+			if err != nil && k8serrors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return resources, err
+			}
+			for _, resource := range resourceList.APIResources {
+				gvr := gv.WithResource(resource.Name)
+				if resource.Name == amaltheaSessionGroupVersionResource.Resource && resources.AmaltheaSession == nil {
+					resources.AmaltheaSession = &gvr
+				}
+				if resource.Name == jupyterServerGroupVersionResource.Resource && resources.JupyterServer == nil {
+					resources.JupyterServer = &gvr
+				}
+			}
+		}
+	}
+
+	return resources, nil
+}
+
+// GetSessionResources returns the session resources served by the current cluster.
+// The discovery is only performed once; if it fails, the default resources are assumed.
+func GetSessionResources() SessionResources {
+	sessionResourcesMu.Lock()
+	defer sessionResourcesMu.Unlock()
+
+	if sessionResources != nil {
+		return *sessionResources
+	}
+
+	resources, err := discoverSessionResources()
+	if err != nil {
+		fmt.Printf("Warning: could not discover session resources, using defaults: %s\n", err)
+		resources = SessionResources{
+			AmaltheaSession: &amaltheaSessionGroupVersionResource,
+			JupyterServer:   &jupyterServerGroupVersionResource,
+		}
+	}
+	sessionResources = &resources
+	return resources
+}
+
+func discoverSessionResources() (resources SessionResources, err error) {
+	client, err := GetDiscoveryClient()
+	if err != nil {
+		return resources, err
+	}
+	return DiscoverSessionResources(client)
+}
+
+// resolveAmaltheaSessionGvr returns gvr if set, otherwise the discovered resource.
+// A NotFound error is returned when the cluster does not serve AmaltheaSessions.
+func resolveAmaltheaSessionGvr(gvr *schema.GroupVersionResource) (*schema.GroupVersionResource, error) {
+	if gvr != nil {
+		return gvr, nil
+	}
+	discovered := GetSessionResources().AmaltheaSession
+	if discovered == nil {
+		return nil, newResourceNotServedError(amaltheaSessionGroupVersionResource.GroupResource())
+	}
+	return discovered, nil
+}
+
+// resolveJupyterServerGvr returns gvr if set, otherwise the discovered resource.
+// A NotFound error is returned when the cluster does not serve JupyterServers.
+func resolveJupyterServerGvr(gvr *schema.GroupVersionResource) (*schema.GroupVersionResource, error) {
+	if gvr != nil {
+		return gvr, nil
+	}
+	discovered := GetSessionResources().JupyterServer
+	if discovered == nil {
+		return nil, newResourceNotServedError(jupyterServerGroupVersionResource.GroupResource())
+	}
+	return discovered, nil
+}
+
+func newResourceNotServedError(gr schema.GroupResource) error {
+	err := k8serrors.NewNotFound(gr, "")
+	err.ErrStatus.Message = fmt.Sprintf("the server does not serve the resource %s", gr.String())
+	return err
+}
@@ -0,0 +1,84 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestDiscoverSessionResources(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		resources []*metav1.APIResourceList
+		out       SessionResources
+	}{
+		{
+			name: "both CRDs installed",
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "amalthea.dev/v1alpha1",
+					APIResources: []metav1.APIResource{{Name: "amaltheasessions"}, {Name: "jupyterservers"}},
+				},
+			},
+			out: SessionResources{
+				AmaltheaSession: &schema.GroupVersionResource{Group: "amalthea.dev", Version: "v1alpha1", Resource: "amaltheasessions"},
+				JupyterServer:   &schema.GroupVersionResource{Group: "amalthea.dev", Version: "v1alpha1", Resource: "jupyterservers"},
+			},
+		},
+		{
+			name: "JupyterServer CRD missing",
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "amalthea.dev/v1alpha1",
+					APIResources: []metav1.APIResource{{Name: "amaltheasessions"}},
+				},
+			},
+			out: SessionResources{
+				AmaltheaSession: &schema.GroupVersionResource{Group: "amalthea.dev", Version: "v1alpha1", Resource: "amaltheasessions"},
+			},
+		},
+		{
+			name: "preferred version wins",
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "amalthea.dev/v1",
+					APIResources: []metav1.APIResource{{Name: "amaltheasessions"}},
+				},
+				{
+					GroupVersion: "amalthea.dev/v1alpha1",
+					APIResources: []metav1.APIResource{{Name: "amaltheasessions"}, {Name: "jupyterservers"}},
+				},
+			},
+			out: SessionResources{
+				AmaltheaSession: &schema.GroupVersionResource{Group: "amalthea.dev", Version: "v1", Resource: "amaltheasessions"},
+				JupyterServer:   &schema.GroupVersionResource{Group: "amalthea.dev", Version: "v1alpha1", Resource: "jupyterservers"},
+			},
+		},
+		{
+			name: "amalthea not installed",
+			resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{{Name: "pods"}},
+				},
+			},
+			out: SessionResources{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: test.resources}}
+			result, err := DiscoverSessionResources(client)
+			require.NoError(t, err)
+			assert.Equal(t, test.out, result)
+		})
+	}
+}
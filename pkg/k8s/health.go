@@ -0,0 +1,156 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+// WorkloadStatus holds the ready and desired replicas of a Deployment or a StatefulSet
+type WorkloadStatus struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Ready      int32  `json:"ready"`
+	Desired    int32  `json:"desired"`
+	Generation int64  `json:"generation"`
+}
+
+func (s WorkloadStatus) IsReady() bool {
+	return s.Ready >= s.Desired
+}
+
+// UnhealthyPod is a pod which is crash looping or stuck in pending
+type UnhealthyPod struct {
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+	LastEvent string `json:"lastEvent"`
+}
+
+func ListWorkloadStatuses(ctx context.Context, clients *kubernetes.Clientset, namespace string) (statuses []WorkloadStatus, err error) {
+	deployments, err := clients.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		desired := int32(1)
+		if deployment.Spec.Replicas != nil {
+			desired = *deployment.Spec.Replicas
+		}
+		statuses = append(statuses, WorkloadStatus{
+			Kind:       "Deployment",
+			Name:       deployment.Name,
+			Ready:      deployment.Status.ReadyReplicas,
+			Desired:    desired,
+			Generation: deployment.Generation,
+		})
+	}
+
+	statefulSets, err := clients.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, sts := range statefulSets.Items {
+		desired := int32(1)
+		if sts.Spec.Replicas != nil {
+			desired = *sts.Spec.Replicas
+		}
+		statuses = append(statuses, WorkloadStatus{
+			Kind:       "StatefulSet",
+			Name:       sts.Name,
+			Ready:      sts.Status.ReadyReplicas,
+			Desired:    desired,
+			Generation: sts.Generation,
+		})
+	}
+
+	return statuses, nil
+}
+
+// ListUnhealthyPods returns the pods in CrashLoopBackOff or Pending, with their last event
+func ListUnhealthyPods(ctx context.Context, clients *kubernetes.Clientset, namespace string) (pods []UnhealthyPod, err error) {
+	podList, err := clients.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		reason := getUnhealthyPodReason(pod)
+		if reason == "" {
+			continue
+		}
+		lastEvent, err := getLastEventMessage(ctx, clients, namespace, pod.Name)
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, UnhealthyPod{Name: pod.Name, Reason: reason, LastEvent: lastEvent})
+	}
+	return pods, nil
+}
+
+func getUnhealthyPodReason(pod *corev1.Pod) string {
+	statuses := slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses)
+	for _, status := range statuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+			return fmt.Sprintf("CrashLoopBackOff (%s)", status.Name)
+		}
+	}
+	if pod.Status.Phase == corev1.PodPending {
+		return string(corev1.PodPending)
+	}
+	return ""
+}
+
+func getLastEventMessage(ctx context.Context, clients *kubernetes.Clientset, namespace string, podName string) (message string, err error) {
+	selector := fields.Set{"involvedObject.kind": "Pod", "involvedObject.name": podName}.AsSelector()
+	events, err := clients.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector.String()})
+	if err != nil {
+		return "", err
+	}
+
+	var last *corev1.Event
+	for i := range events.Items {
+		event := &events.Items[i]
+		if last == nil || getEventTime(event).After(getEventTime(last).Time) {
+			last = event
+		}
+	}
+	if last == nil {
+		return "", nil
+	}
+	return fmt.Sprintf("%s: %s", last.Reason, last.Message), nil
+}
+
+func getEventTime(event *corev1.Event) metav1.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp
+	}
+	if !event.EventTime.IsZero() {
+		return metav1.NewTime(event.EventTime.Time)
+	}
+	return event.CreationTimestamp
+}
+
+// ListFailedJobs returns the names of the jobs which have failed
+func ListFailedJobs(ctx context.Context, clients *kubernetes.Clientset, namespace string) (jobs []string, err error) {
+	jobList, err := ListJobs(ctx, clients, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range jobList.Items {
+		for _, condition := range job.Status.Conditions {
+			if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+				jobs = append(jobs, job.Name)
+				break
+			}
+		}
+	}
+	return jobs, nil
+}
@@ -0,0 +1,174 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+var httpRouteGroupVersionResource = schema.GroupVersionResource{
+	Group:    "gateway.networking.k8s.io",
+	Version:  "v1",
+	Resource: "httproutes",
+}
+
+var gatewayGroupVersionResource = schema.GroupVersionResource{
+	Group:    "gateway.networking.k8s.io",
+	Version:  "v1",
+	Resource: "gateways",
+}
+
+// FindDeploymentURL returns the URL exposed by the Ingresses of a namespace,
+// or by its Gateway API HTTPRoutes if there are no Ingresses.
+// It returns nil if the namespace does not expose any host.
+func FindDeploymentURL(ctx context.Context, clients *kubernetes.Clientset, client *dynamic.DynamicClient, namespace string) (deploymentURL *url.URL, err error) {
+	ingressList, err := clients.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	deploymentURL = findIngressURL(ingressList.Items)
+	if deploymentURL != nil {
+		return deploymentURL, nil
+	}
+
+	routeList, err := client.Resource(httpRouteGroupVersionResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if k8serrors.IsNotFound(err) {
+		// The Gateway API is not installed
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	host := pickHost(getHTTPRouteHosts(routeList.Items))
+	if host == "" {
+		return nil, nil
+	}
+
+	scheme := "https"
+	for _, route := range routeList.Items {
+		routeHosts, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+		if !slices.Contains(routeHosts, host) {
+			continue
+		}
+		gatewayScheme, err := getHTTPRouteScheme(ctx, client, &route, host)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not inspect the gateway of HTTPRoute '%s': %s\n", route.GetName(), err.Error())
+			break
+		}
+		scheme = gatewayScheme
+		break
+	}
+	return &url.URL{Scheme: scheme, Host: host}, nil
+}
+
+// findIngressURL returns the most common host of the ingresses, with https if it has TLS configured
+func findIngressURL(ingresses []networkingv1.Ingress) *url.URL {
+	hosts := []string{}
+	for _, ingress := range ingresses {
+		for _, rule := range ingress.Spec.Rules {
+			if rule.Host != "" {
+				hosts = append(hosts, rule.Host)
+			}
+		}
+	}
+	host := pickHost(hosts)
+	if host == "" {
+		return nil
+	}
+
+	scheme := "http"
+	for _, ingress := range ingresses {
+		for _, tls := range ingress.Spec.TLS {
+			if slices.Contains(tls.Hosts, host) {
+				scheme = "https"
+			}
+		}
+	}
+	return &url.URL{Scheme: scheme, Host: host}
+}
+
+func getHTTPRouteHosts(routes []unstructured.Unstructured) (hosts []string) {
+	for _, route := range routes {
+		routeHosts, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+		hosts = append(hosts, routeHosts...)
+	}
+	return hosts
+}
+
+// getHTTPRouteScheme returns https if a parent gateway of the route has an HTTPS listener for host
+func getHTTPRouteScheme(ctx context.Context, client *dynamic.DynamicClient, route *unstructured.Unstructured, host string) (scheme string, err error) {
+	parentRefs, _, err := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	if err != nil {
+		return "", err
+	}
+	for _, parentRef := range parentRefs {
+		ref, ok := parentRef.(map[string]any)
+		if !ok {
+			continue
+		}
+		kind, _, _ := unstructured.NestedString(ref, "kind")
+		if kind != "" && kind != "Gateway" {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(ref, "name")
+		namespace, _, _ := unstructured.NestedString(ref, "namespace")
+		if namespace == "" {
+			namespace = route.GetNamespace()
+		}
+		gateway, err := client.Resource(gatewayGroupVersionResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		listeners, _, _ := unstructured.NestedSlice(gateway.Object, "spec", "listeners")
+		if hasHTTPSListener(listeners, host) {
+			return "https", nil
+		}
+	}
+	return "http", nil
+}
+
+func hasHTTPSListener(listeners []any, host string) bool {
+	for _, listener := range listeners {
+		l, ok := listener.(map[string]any)
+		if !ok {
+			continue
+		}
+		protocol, _, _ := unstructured.NestedString(l, "protocol")
+		if protocol != "HTTPS" {
+			continue
+		}
+		hostname, _, _ := unstructured.NestedString(l, "hostname")
+		if hostname == "" || hostname == host || matchesWildcardHost(hostname, host) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesWildcardHost(pattern string, host string) bool {
+	suffix, found := strings.CutPrefix(pattern, "*")
+	return found && strings.HasPrefix(suffix, ".") && strings.HasSuffix(host, suffix) && len(host) > len(suffix)
+}
+
+// pickHost returns the most common host, the first one on a tie
+func pickHost(hosts []string) string {
+	counts := map[string]int{}
+	best := ""
+	for _, host := range hosts {
+		counts[host]++
+		if counts[host] > counts[best] {
+			best = host
+		}
+	}
+	return best
+}
@@ -0,0 +1,46 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func newIngress(hosts []string, tlsHosts []string) networkingv1.Ingress {
+	ingress := networkingv1.Ingress{}
+	for _, host := range hosts {
+		ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{Host: host})
+	}
+	if len(tlsHosts) > 0 {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{{Hosts: tlsHosts}}
+	}
+	return ingress
+}
+
+func TestFindIngressURL(t *testing.T) {
+	t.Parallel()
+
+	deploymentURL := findIngressURL([]networkingv1.Ingress{
+		newIngress([]string{"minio.example.org"}, nil),
+		newIngress([]string{"renku.example.org"}, []string{"renku.example.org"}),
+		newIngress([]string{"renku.example.org"}, nil),
+	})
+	assert.Equal(t, "https://renku.example.org", deploymentURL.String())
+
+	deploymentURL = findIngressURL([]networkingv1.Ingress{newIngress([]string{"renku.local"}, nil)})
+	assert.Equal(t, "http://renku.local", deploymentURL.String())
+
+	assert.Nil(t, findIngressURL([]networkingv1.Ingress{newIngress([]string{""}, nil)}))
+}
+
+func TestHasHTTPSListener(t *testing.T) {
+	t.Parallel()
+
+	listeners := []any{
+		map[string]any{"protocol": "HTTP"},
+		map[string]any{"protocol": "HTTPS", "hostname": "*.dev.renku.ch"},
+	}
+	assert.True(t, hasHTTPSListener(listeners, "renku-ci-ds-1.dev.renku.ch"))
+	assert.False(t, hasHTTPSListener(listeners, "renku.example.org"))
+}
@@ -0,0 +1,13 @@
+package k8s
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+func ListJobs(ctx context.Context, clients *kubernetes.Clientset, namespace string) (jobList *batchv1.JobList, err error) {
+	return clients.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+}
@@ -7,18 +7,21 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 )
 
+// Default JupyterServer resource, used when the discovery API is not available
 var jupyterServerGroupVersionResource = schema.GroupVersionResource{
-	Group:    "amalthea.dev",
+	Group:    amaltheaGroup,
 	Version:  "v1alpha1",
 	Resource: "jupyterservers",
 }
 
 func ListJupyterServers(ctx context.Context, client *dynamic.DynamicClient, namespace string, gvr *schema.GroupVersionResource) (servers []string, err error) {
-	if gvr == nil {
-		gvr = &jupyterServerGroupVersionResource
+	gvr, err = resolveJupyterServerGvr(gvr)
+	if err != nil {
+		return nil, err
 	}
 
 	jss, err := client.Resource(*gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
@@ -33,12 +36,13 @@ func ListJupyterServers(ctx context.Context, client *dynamic.DynamicClient, name
 }
 
 func DeleteJupyterServer(ctx context.Context, client *dynamic.DynamicClient, namespace string, name string, gvr *schema.GroupVersionResource) error {
-	if gvr == nil {
-		gvr = &jupyterServerGroupVersionResource
+	gvr, err := resolveJupyterServerGvr(gvr)
+	if err != nil {
+		return err
 	}
 
 	propagation := metav1.DeletePropagationBackground
-	err := client.Resource(*gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	err = client.Resource(*gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &propagation})
 	if err != nil {
 		return err
 	}
@@ -46,12 +50,16 @@ func DeleteJupyterServer(ctx context.Context, client *dynamic.DynamicClient, nam
 }
 
 func DeleteJupyterServers(ctx context.Context, client *dynamic.DynamicClient, namespace string, gvr *schema.GroupVersionResource) error {
-	if gvr == nil {
-		gvr = &jupyterServerGroupVersionResource
+	gvr, err := resolveJupyterServerGvr(gvr)
+	if err != nil && k8serrors.IsNotFound(err) {
+		fmt.Printf("Warning: %s\n", err)
+		return nil
+	} else if err != nil {
+		return err
 	}
 
 	propagation := metav1.DeletePropagationBackground
-	err := client.Resource(*gvr).Namespace(namespace).DeleteCollection(ctx, metav1.DeleteOptions{PropagationPolicy: &propagation}, metav1.ListOptions{})
+	err = client.Resource(*gvr).Namespace(namespace).DeleteCollection(ctx, metav1.DeleteOptions{PropagationPolicy: &propagation}, metav1.ListOptions{})
 	if err != nil && k8serrors.IsNotFound(err) {
 		fmt.Printf("Warning: Resource not found: %s\n", gvr.String())
 		return nil
@@ -62,8 +70,9 @@ func DeleteJupyterServers(ctx context.Context, client *dynamic.DynamicClient, na
 }
 
 func ForciblyDeleteJupyterServer(ctx context.Context, client *dynamic.DynamicClient, namespace string, name string, gvr *schema.GroupVersionResource) error {
-	if gvr == nil {
-		gvr = &jupyterServerGroupVersionResource
+	gvr, err := resolveJupyterServerGvr(gvr)
+	if err != nil {
+		return err
 	}
 
 	js, err := client.Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -84,13 +93,25 @@ func ForciblyDeleteJupyterServer(ctx context.Context, client *dynamic.DynamicCli
 	return nil
 }
 
+// SetJupyterServerHibernated hibernates or resumes a JupyterServer
+func SetJupyterServerHibernated(ctx context.Context, client *dynamic.DynamicClient, namespace string, name string, hibernated bool, gvr *schema.GroupVersionResource) error {
+	gvr, err := resolveJupyterServerGvr(gvr)
+	if err != nil {
+		return err
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"jupyterServer":{"hibernated":%t}}}`, hibernated)
+	_, err = client.Resource(*gvr).Namespace(namespace).Patch(ctx, name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func ForciblyDeleteJupyterServers(ctx context.Context, client *dynamic.DynamicClient, namespace string, gvr *schema.GroupVersionResource) error {
 	servers, err := ListJupyterServers(ctx, client, namespace, gvr)
 	if err != nil && k8serrors.IsNotFound(err) {
-		if gvr == nil {
-			gvr = &jupyterServerGroupVersionResource
-		}
-		fmt.Printf("Warning: Resource not found: %s\n", gvr.String())
+		fmt.Printf("Warning: %s\n", err)
 		return nil
 	} else if err != nil {
 		return err
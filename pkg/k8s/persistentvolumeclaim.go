@@ -0,0 +1,23 @@
+package k8s
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+func ListPersistentVolumeClaims(ctx context.Context, clients *kubernetes.Clientset, namespace string) (pvcList *corev1.PersistentVolumeClaimList, err error) {
+	return clients.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+}
+
+// GetPersistentVolumeClaimSize returns the capacity of a bound PVC, or its
+// requested storage if the claim is not bound yet.
+func GetPersistentVolumeClaimSize(pvc *corev1.PersistentVolumeClaim) (size resource.Quantity) {
+	if capacity, found := pvc.Status.Capacity[corev1.ResourceStorage]; found {
+		return capacity
+	}
+	return pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+}
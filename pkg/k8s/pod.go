@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Labels which amalthea puts on the pods of a session
+var sessionPodLabelKeys = []string{"amalthea.dev/parent-name", "app.kubernetes.io/instance"}
+
+// PodContainer is a container of a pod
+type PodContainer struct {
+	Name string
+	Init bool
+}
+
+// FindSessionPods returns the pods of a session.
+// Pods are matched through owner references first and through labels otherwise.
+func FindSessionPods(ctx context.Context, clients *kubernetes.Clientset, client *dynamic.DynamicClient, namespace string, name string) (pods []corev1.Pod, err error) {
+	kind, err := FindSessionKind(ctx, client, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	gvr := GetSessionResources().AmaltheaSession
+	if kind == JupyterServerKind {
+		gvr = GetSessionResources().JupyterServer
+	}
+	session, err := client.Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// The session owns a StatefulSet which owns the pods
+	owners := map[types.UID]bool{session.GetUID(): true}
+	statefulSets, err := clients.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, sts := range statefulSets.Items {
+		if isOwnedBy(sts.OwnerReferences, owners) {
+			owners[sts.UID] = true
+		}
+	}
+
+	podList, err := clients.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range podList.Items {
+		if isOwnedBy(pod.OwnerReferences, owners) {
+			pods = append(pods, pod)
+		}
+	}
+	if len(pods) > 0 {
+		return pods, nil
+	}
+
+	for _, key := range sessionPodLabelKeys {
+		selector := labels.SelectorFromSet(labels.Set{key: name})
+		podList, err := clients.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, err
+		}
+		if len(podList.Items) > 0 {
+			return podList.Items, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not find any pod for %s '%s'", kind, name)
+}
+
+func isOwnedBy(ownerReferences []metav1.OwnerReference, owners map[types.UID]bool) bool {
+	for _, ref := range ownerReferences {
+		if owners[ref.UID] {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPodContainers returns the init containers and the containers of a pod, in start order
+func GetPodContainers(pod *corev1.Pod) (containers []PodContainer) {
+	for _, container := range pod.Spec.InitContainers {
+		containers = append(containers, PodContainer{Name: container.Name, Init: true})
+	}
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, PodContainer{Name: container.Name})
+	}
+	return containers
+}
+
+// StreamPodLogs copies the logs of a container to out
+func StreamPodLogs(ctx context.Context, clients *kubernetes.Clientset, namespace string, pod string, opts *corev1.PodLogOptions, out io.Writer) error {
+	stream, err := clients.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := stream.Close(); err != nil {
+			fmt.Printf("Warning, could not close log stream: %s", err.Error())
+		}
+	}()
+
+	_, err = io.Copy(out, stream)
+	return err
+}
@@ -0,0 +1,135 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// ServiceTarget is a ready pod serving a port of a service
+type ServiceTarget struct {
+	Service string
+	Pod     string
+	PodPort int32
+}
+
+// FindService returns the first service which exists among the candidate names.
+// If none of them exist, the first service whose name contains hint is returned.
+func FindService(ctx context.Context, clients *kubernetes.Clientset, namespace string, candidates []string, hint string) (service *corev1.Service, err error) {
+	serviceList, err := clients.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		for i := range serviceList.Items {
+			if serviceList.Items[i].Name == candidate {
+				return &serviceList.Items[i], nil
+			}
+		}
+	}
+	for i := range serviceList.Items {
+		if hint != "" && strings.Contains(serviceList.Items[i].Name, hint) {
+			return &serviceList.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("could not find a service for '%s' in namespace '%s'", hint, namespace)
+}
+
+// FindServiceTarget resolves the first port of a service to a ready pod and a container port
+func FindServiceTarget(ctx context.Context, clients *kubernetes.Clientset, service *corev1.Service) (target ServiceTarget, err error) {
+	if len(service.Spec.Ports) == 0 {
+		return target, fmt.Errorf("service '%s' does not expose any port", service.Name)
+	}
+	if len(service.Spec.Selector) == 0 {
+		return target, fmt.Errorf("service '%s' does not have a pod selector", service.Name)
+	}
+	servicePort := service.Spec.Ports[0]
+
+	selector := labels.SelectorFromSet(service.Spec.Selector)
+	podList, err := clients.CoreV1().Pods(service.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return target, err
+	}
+
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !isPodReady(pod) {
+			continue
+		}
+		podPort, err := resolveTargetPort(pod, servicePort)
+		if err != nil {
+			return target, err
+		}
+		return ServiceTarget{Service: service.Name, Pod: pod.Name, PodPort: podPort}, nil
+	}
+	return target, fmt.Errorf("could not find a ready pod for service '%s'", service.Name)
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func resolveTargetPort(pod *corev1.Pod, servicePort corev1.ServicePort) (port int32, err error) {
+	targetPort := servicePort.TargetPort
+	if targetPort.IntVal != 0 {
+		return targetPort.IntVal, nil
+	}
+	if targetPort.StrVal == "" {
+		return servicePort.Port, nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == targetPort.StrVal {
+				return containerPort.ContainerPort, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("could not resolve port '%s' in pod '%s'", targetPort.StrVal, pod.Name)
+}
+
+// PortForward forwards localPort on address to podPort of a pod.
+// It blocks until ctx is done; ready is closed once the forwarding is active.
+func PortForward(ctx context.Context, clients *kubernetes.Clientset, namespace string, pod string, address string, localPort int32, podPort int32, ready chan struct{}, out io.Writer) error {
+	config, err := getRestConfig()
+	if err != nil {
+		return err
+	}
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return err
+	}
+
+	req := clients.CoreV1().RESTClient().Post().Resource("pods").Namespace(namespace).Name(pod).SubResource("portforward")
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+
+	stop := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		close(stop)
+	}()
+
+	ports := []string{fmt.Sprintf("%d:%d", localPort, podPort)}
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{address}, ports, stop, ready, out, out)
+	if err != nil {
+		return err
+	}
+	return forwarder.ForwardPorts()
+}
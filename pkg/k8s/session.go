@@ -0,0 +1,177 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	AmaltheaSessionKind string = "AmaltheaSession"
+	JupyterServerKind   string = "JupyterServer"
+)
+
+// Labels and annotations which may hold the owner of a session
+var sessionOwnerKeys = []string{"renku.io/safe-username", "renku.io/userId", "renku.io/username"}
+
+// Annotations which may hold the resource class of a session
+var sessionResourceClassKeys = []string{"renku.io/resource_class_id", "renku.io/resourceClassId"}
+
+// SessionInfo summarizes an AmaltheaSession or a JupyterServer
+type SessionInfo struct {
+	Kind          string    `json:"kind"`
+	Name          string    `json:"name"`
+	Owner         string    `json:"owner"`
+	State         string    `json:"state"`
+	Image         string    `json:"image"`
+	ResourceClass string    `json:"resourceClass"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// ListSessions returns the AmaltheaSessions and JupyterServers of a namespace.
+// Session kinds which are not served by the cluster are skipped.
+func ListSessions(ctx context.Context, client *dynamic.DynamicClient, namespace string) (sessions []SessionInfo, err error) {
+	resources := GetSessionResources()
+
+	if resources.AmaltheaSession != nil {
+		list, err := client.Resource(*resources.AmaltheaSession).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			for i := range list.Items {
+				sessions = append(sessions, GetAmaltheaSessionInfo(&list.Items[i]))
+			}
+		}
+	}
+
+	if resources.JupyterServer != nil {
+		list, err := client.Resource(*resources.JupyterServer).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			for i := range list.Items {
+				sessions = append(sessions, GetJupyterServerInfo(&list.Items[i]))
+			}
+		}
+	}
+
+	return sessions, nil
+}
+
+func GetAmaltheaSessionInfo(session *unstructured.Unstructured) SessionInfo {
+	info := newSessionInfo(AmaltheaSessionKind, session)
+	info.Image, _, _ = unstructured.NestedString(session.Object, "spec", "session", "image")
+	hibernated, _, _ := unstructured.NestedBool(session.Object, "spec", "hibernated")
+	info.State = getSessionState(session, hibernated)
+	return info
+}
+
+func GetJupyterServerInfo(server *unstructured.Unstructured) SessionInfo {
+	info := newSessionInfo(JupyterServerKind, server)
+	info.Image, _, _ = unstructured.NestedString(server.Object, "spec", "jupyterServer", "image")
+	hibernated, _, _ := unstructured.NestedBool(server.Object, "spec", "jupyterServer", "hibernated")
+	info.State = getSessionState(server, hibernated)
+	return info
+}
+
+func newSessionInfo(kind string, obj *unstructured.Unstructured) SessionInfo {
+	return SessionInfo{
+		Kind:          kind,
+		Name:          obj.GetName(),
+		Owner:         findLabelOrAnnotation(obj, sessionOwnerKeys),
+		ResourceClass: findLabelOrAnnotation(obj, sessionResourceClassKeys),
+		CreatedAt:     obj.GetCreationTimestamp().Time,
+	}
+}
+
+func getSessionState(obj *unstructured.Unstructured, hibernated bool) string {
+	if obj.GetDeletionTimestamp() != nil {
+		return "terminating"
+	}
+	if hibernated {
+		return "hibernated"
+	}
+	state, _, _ := unstructured.NestedString(obj.Object, "status", "state")
+	if state == "" {
+		return "unknown"
+	}
+	return strings.ToLower(state)
+}
+
+func findLabelOrAnnotation(obj *unstructured.Unstructured, keys []string) string {
+	labels := obj.GetLabels()
+	annotations := obj.GetAnnotations()
+	for _, key := range keys {
+		if value := labels[key]; value != "" {
+			return value
+		}
+		if value := annotations[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// FindSessionKind returns the kind of the session with the given name,
+// looking first for an AmaltheaSession and then for a JupyterServer.
+func FindSessionKind(ctx context.Context, client *dynamic.DynamicClient, namespace string, name string) (kind string, err error) {
+	resources := GetSessionResources()
+
+	if resources.AmaltheaSession != nil {
+		_, err := client.Resource(*resources.AmaltheaSession).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			return AmaltheaSessionKind, nil
+		} else if !k8serrors.IsNotFound(err) {
+			return "", err
+		}
+	}
+
+	if resources.JupyterServer != nil {
+		_, err := client.Resource(*resources.JupyterServer).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			return JupyterServerKind, nil
+		} else if !k8serrors.IsNotFound(err) {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("could not find session '%s' in namespace '%s'", name, namespace)
+}
+
+// WaitForSessionDeletion waits until a session is gone or the timeout passes, and returns whether it is gone
+func WaitForSessionDeletion(ctx context.Context, client *dynamic.DynamicClient, namespace string, name string, kind string, timeout time.Duration) (deleted bool, err error) {
+	gvr := GetSessionResources().AmaltheaSession
+	if kind == JupyterServerKind {
+		gvr = GetSessionResources().JupyterServer
+	}
+	if gvr == nil {
+		return false, fmt.Errorf("%s is not served by the cluster", kind)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		_, err := client.Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		} else if err != nil {
+			return false, err
+		}
+		if time.Now().After(deadline) {
+			return false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
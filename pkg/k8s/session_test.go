@@ -0,0 +1,150 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestGetAmaltheaSessionInfo(t *testing.T) {
+	t.Parallel()
+	// Timestamps of unstructured objects are read back in the local time zone
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Local()
+	tests := []struct {
+		name    string
+		session map[string]any
+		deleted bool
+		out     SessionInfo
+	}{
+		{
+			name: "running session",
+			session: map[string]any{
+				"metadata": map[string]any{
+					"name":        "alice-1234",
+					"labels":      map[string]any{"renku.io/safe-username": "alice"},
+					"annotations": map[string]any{"renku.io/resource_class_id": "2"},
+				},
+				"spec":   map[string]any{"session": map[string]any{"image": "renku/py-basic:1.0"}},
+				"status": map[string]any{"state": "Running"},
+			},
+			out: SessionInfo{Kind: AmaltheaSessionKind, Name: "alice-1234", Owner: "alice", State: "running", Image: "renku/py-basic:1.0", ResourceClass: "2", CreatedAt: createdAt},
+		},
+		{
+			name: "hibernated session",
+			session: map[string]any{
+				"metadata": map[string]any{
+					"name":        "bob-5678",
+					"annotations": map[string]any{"renku.io/userId": "bob"},
+				},
+				"spec":   map[string]any{"hibernated": true, "session": map[string]any{"image": "renku/py-basic:1.0"}},
+				"status": map[string]any{"state": "Running"},
+			},
+			out: SessionInfo{Kind: AmaltheaSessionKind, Name: "bob-5678", Owner: "bob", State: "hibernated", Image: "renku/py-basic:1.0", CreatedAt: createdAt},
+		},
+		{
+			name: "terminating session",
+			session: map[string]any{
+				"metadata": map[string]any{"name": "alice-1234"},
+				"spec":     map[string]any{"hibernated": true},
+				"status":   map[string]any{"state": "Running"},
+			},
+			deleted: true,
+			out:     SessionInfo{Kind: AmaltheaSessionKind, Name: "alice-1234", State: "terminating", CreatedAt: createdAt},
+		},
+		{
+			name: "session without status",
+			session: map[string]any{
+				"metadata": map[string]any{"name": "alice-1234"},
+			},
+			out: SessionInfo{Kind: AmaltheaSessionKind, Name: "alice-1234", State: "unknown", CreatedAt: createdAt},
+		},
+		{
+			name: "malformed fields",
+			session: map[string]any{
+				"metadata": map[string]any{"name": "alice-1234"},
+				"spec":     map[string]any{"hibernated": "yes", "session": map[string]any{"image": 42}},
+				"status":   map[string]any{"state": 1},
+			},
+			out: SessionInfo{Kind: AmaltheaSessionKind, Name: "alice-1234", State: "unknown", CreatedAt: createdAt},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			session := newTestSession(test.session, createdAt, test.deleted)
+			assert.Equal(t, test.out, GetAmaltheaSessionInfo(session))
+		})
+	}
+}
+
+func TestGetJupyterServerInfo(t *testing.T) {
+	t.Parallel()
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Local()
+	tests := []struct {
+		name    string
+		server  map[string]any
+		deleted bool
+		out     SessionInfo
+	}{
+		{
+			name: "running server",
+			server: map[string]any{
+				"metadata": map[string]any{
+					"name":   "alice-1234",
+					"labels": map[string]any{"renku.io/username": "alice"},
+				},
+				"spec":   map[string]any{"jupyterServer": map[string]any{"image": "renku/renkulab-py:3.10"}},
+				"status": map[string]any{"state": "running"},
+			},
+			out: SessionInfo{Kind: JupyterServerKind, Name: "alice-1234", Owner: "alice", State: "running", Image: "renku/renkulab-py:3.10", CreatedAt: createdAt},
+		},
+		{
+			name: "hibernated server",
+			server: map[string]any{
+				"metadata": map[string]any{"name": "alice-1234"},
+				"spec":     map[string]any{"jupyterServer": map[string]any{"hibernated": true, "image": "renku/renkulab-py:3.10"}},
+				"status":   map[string]any{"state": "stopping"},
+			},
+			out: SessionInfo{Kind: JupyterServerKind, Name: "alice-1234", State: "hibernated", Image: "renku/renkulab-py:3.10", CreatedAt: createdAt},
+		},
+		{
+			name: "hibernated flag of an AmaltheaSession is ignored",
+			server: map[string]any{
+				"metadata": map[string]any{"name": "alice-1234"},
+				"spec":     map[string]any{"hibernated": true},
+				"status":   map[string]any{"state": "Failed"},
+			},
+			out: SessionInfo{Kind: JupyterServerKind, Name: "alice-1234", State: "failed", CreatedAt: createdAt},
+		},
+		{
+			name: "terminating server",
+			server: map[string]any{
+				"metadata": map[string]any{"name": "alice-1234"},
+			},
+			deleted: true,
+			out:     SessionInfo{Kind: JupyterServerKind, Name: "alice-1234", State: "terminating", CreatedAt: createdAt},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newTestSession(test.server, createdAt, test.deleted)
+			assert.Equal(t, test.out, GetJupyterServerInfo(server))
+		})
+	}
+}
+
+func newTestSession(object map[string]any, createdAt time.Time, deleted bool) *unstructured.Unstructured {
+	session := &unstructured.Unstructured{Object: object}
+	session.SetCreationTimestamp(metav1.NewTime(createdAt))
+	if deleted {
+		deletedAt := metav1.NewTime(createdAt.Add(time.Hour))
+		session.SetDeletionTimestamp(&deletedAt)
+	}
+	return session
+}
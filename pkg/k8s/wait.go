@@ -0,0 +1,164 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var jobGroupVersionResource = schema.GroupVersionResource{
+	Group:    "batch",
+	Version:  "v1",
+	Resource: "jobs",
+}
+
+var persistentVolumeClaimGroupVersionResource = schema.GroupVersionResource{
+	Group:    "",
+	Version:  "v1",
+	Resource: "persistentvolumeclaims",
+}
+
+var namespaceGroupVersionResource = schema.GroupVersionResource{
+	Group:    "",
+	Version:  "v1",
+	Resource: "namespaces",
+}
+
+// How often the cluster is polled while waiting for resources to be deleted
+const waitPollInterval = 2 * time.Second
+
+// StuckResource is a resource which was not deleted in time
+type StuckResource struct {
+	Gvr        schema.GroupVersionResource
+	Namespace  string
+	Name       string
+	Finalizers []string
+}
+
+func (r StuckResource) String() string {
+	return fmt.Sprintf("%s/%s", r.Gvr.Resource, r.Name)
+}
+
+// WaitForCleanup waits until the sessions, jobs and PVCs of a namespace are gone,
+// and the namespace itself if deleteNamespace is set.
+// Resources which are still present when the timeout expires are returned.
+func WaitForCleanup(ctx context.Context, client *dynamic.DynamicClient, namespace string, deleteNamespace bool, timeout time.Duration) (stuck []StuckResource, err error) {
+	deadline := time.Now().Add(timeout)
+
+	gvrs := []schema.GroupVersionResource{}
+	sessionResources := GetSessionResources()
+	if sessionResources.AmaltheaSession != nil {
+		gvrs = append(gvrs, *sessionResources.AmaltheaSession)
+	}
+	if sessionResources.JupyterServer != nil {
+		gvrs = append(gvrs, *sessionResources.JupyterServer)
+	}
+	gvrs = append(gvrs, jobGroupVersionResource, persistentVolumeClaimGroupVersionResource)
+	for _, gvr := range gvrs {
+		fmt.Printf("Waiting for %s to be deleted\n", gvr.Resource)
+		remaining, err := waitForDeletion(ctx, client, gvr, namespace, metav1.ListOptions{}, deadline)
+		if err != nil {
+			return stuck, err
+		}
+		stuck = append(stuck, remaining...)
+	}
+
+	if deleteNamespace {
+		fmt.Printf("Waiting for the namespace '%s' to be deleted\n", namespace)
+		opts := metav1.ListOptions{FieldSelector: fields.OneTermEqualSelector("metadata.name", namespace).String()}
+		remaining, err := waitForDeletion(ctx, client, namespaceGroupVersionResource, "", opts, deadline)
+		if err != nil {
+			return stuck, err
+		}
+		stuck = append(stuck, remaining...)
+	}
+
+	return stuck, nil
+}
+
+func waitForDeletion(ctx context.Context, client *dynamic.DynamicClient, gvr schema.GroupVersionResource, namespace string, opts metav1.ListOptions, deadline time.Time) (stuck []StuckResource, err error) {
+	for {
+		list, err := client.Resource(gvr).Namespace(namespace).List(ctx, opts)
+		if err != nil && k8serrors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+		// Objects which are not being deleted, e.g. created after the cleanup, are not waited for
+		terminating := []*unstructured.Unstructured{}
+		for i := range list.Items {
+			if list.Items[i].GetDeletionTimestamp() != nil {
+				terminating = append(terminating, &list.Items[i])
+			}
+		}
+		if len(terminating) == 0 {
+			return nil, nil
+		}
+
+		if time.Now().After(deadline) {
+			for _, obj := range terminating {
+				stuck = append(stuck, newStuckResource(gvr, obj))
+			}
+			return stuck, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
+
+func newStuckResource(gvr schema.GroupVersionResource, obj *unstructured.Unstructured) StuckResource {
+	finalizers := obj.GetFinalizers()
+	// Namespaces also carry finalizers in their spec
+	if gvr == namespaceGroupVersionResource {
+		specFinalizers, _, _ := unstructured.NestedStringSlice(obj.Object, "spec", "finalizers")
+		finalizers = append(finalizers, specFinalizers...)
+	}
+	return StuckResource{
+		Gvr:        gvr,
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Finalizers: finalizers,
+	}
+}
+
+// RemoveFinalizers removes all the finalizers of a stuck resource so that it can be deleted
+func RemoveFinalizers(ctx context.Context, client *dynamic.DynamicClient, resource StuckResource) error {
+	obj, err := client.Resource(resource.Gvr).Namespace(resource.Namespace).Get(ctx, resource.Name, metav1.GetOptions{})
+	if err != nil && k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if len(obj.GetFinalizers()) > 0 {
+		obj.SetFinalizers(nil)
+		obj, err = client.Resource(resource.Gvr).Namespace(resource.Namespace).Update(ctx, obj, metav1.UpdateOptions{})
+		if err != nil && k8serrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	// Namespace spec finalizers can only be removed through the "finalize" subresource
+	if resource.Gvr == namespaceGroupVersionResource {
+		unstructured.RemoveNestedField(obj.Object, "spec", "finalizers")
+		_, err = client.Resource(resource.Gvr).Update(ctx, obj, metav1.UpdateOptions{}, "finalize")
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,15 +1,61 @@
 package namespace
 
 import (
+	"context"
 	"fmt"
 	"net/url"
+	"os"
+	"strings"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
 )
 
+// The domain of the CI deployments
+const deploymentDomain string = "dev.renku.ch"
+
+// GetDeploymentURL returns the URL of a deployment following the CI hostname convention
 func GetDeploymentURL(namespace string) (deploymentURL *url.URL, err error) {
-	// TODO: Can we derive the URL by inspecting ingresses in the k8s namespace?
-	openURL, err := url.Parse(fmt.Sprintf("https://%s.dev.renku.ch", namespace))
+	openURL, err := url.Parse(fmt.Sprintf("https://%s.%s", namespace, deploymentDomain))
 	if err != nil {
 		return nil, err
 	}
 	return openURL, nil
 }
+
+// GetDeploymentNamespace returns the namespace of a deployment URL following the CI hostname convention
+func GetDeploymentNamespace(deploymentURL string) (namespace string, found bool) {
+	parsedURL, err := url.Parse(deploymentURL)
+	if err != nil {
+		return "", false
+	}
+	namespace, found = strings.CutSuffix(parsedURL.Hostname(), "."+deploymentDomain)
+	if !found || namespace == "" || strings.Contains(namespace, ".") {
+		return "", false
+	}
+	return namespace, true
+}
+
+// ResolveDeploymentURL returns the URL exposed by the Ingresses or HTTPRoutes of a deployment.
+// It falls back to GetDeploymentURL if the namespace does not expose any host or cannot be inspected.
+func ResolveDeploymentURL(ctx context.Context, namespace string) (deploymentURL *url.URL, err error) {
+	deploymentURL, err = findDeploymentURL(ctx, namespace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not inspect the ingresses of namespace '%s': %s\n", namespace, err.Error())
+	}
+	if deploymentURL != nil {
+		return deploymentURL, nil
+	}
+	return GetDeploymentURL(namespace)
+}
+
+func findDeploymentURL(ctx context.Context, namespace string) (deploymentURL *url.URL, err error) {
+	clients, err := k8s.GetClientset()
+	if err != nil {
+		return nil, err
+	}
+	client, err := k8s.GetDynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	return k8s.FindDeploymentURL(ctx, clients, client, namespace)
+}
@@ -3,30 +3,31 @@ package namespace
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
 )
 
-func FindCurrentNamespace(ctx context.Context, cli *github.GitHubCLI) (namespace string, err error) {
+func FindCurrentNamespace(ctx context.Context, cli github.GitHubClient) (namespace string, err error) {
 	repo, err := cli.GetCurrentRepository(ctx)
 	if err != nil {
 		return "", err
 	}
-	fmt.Printf("Repository: %s", repo)
-	fmt.Println()
+	fmt.Fprintf(os.Stderr, "Repository: %s", repo)
+	fmt.Fprintln(os.Stderr)
 
 	prNumber, err := cli.GetCurrentPullRequest(ctx)
 	if err != nil {
 		return "", err
 	}
-	fmt.Printf("Pull request: %d", prNumber)
-	fmt.Println()
+	fmt.Fprintf(os.Stderr, "Pull request: %d", prNumber)
+	fmt.Fprintln(os.Stderr)
 
 	namespace, err = github.DeriveK8sNamespace(repo, prNumber)
 	if err != nil {
 		return "", err
 	}
-	fmt.Printf("Derived namespace: %s", namespace)
-	fmt.Println()
+	fmt.Fprintf(os.Stderr, "Derived namespace: %s", namespace)
+	fmt.Fprintln(os.Stderr)
 	return namespace, nil
 }
@@ -0,0 +1,121 @@
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+	"github.com/lithammer/fuzzysearch/fuzzy"
+	"github.com/manifoldco/promptui"
+	"golang.org/x/term"
+)
+
+// How many pull request states are fetched at the same time
+const pullRequestStateConcurrency = 8
+
+// PickerSource lets the user pick a deployment interactively.
+// It does not apply when stdin or stdout is not a terminal.
+type PickerSource struct{}
+
+func (s PickerSource) Name() string {
+	return "interactive selection"
+}
+
+func (s PickerSource) Resolve(ctx context.Context) (namespace string, err error) {
+	if !isInteractive() {
+		return "", nil
+	}
+
+	candidates, err := listDeploymentCandidates(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no deployment found in the cluster")
+	}
+
+	labels := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		labels = append(labels, candidate.String())
+	}
+	prompt := promptui.Select{
+		Label:             "Select a deployment (type to search)",
+		Items:             labels,
+		Size:              15,
+		Searcher:          newFuzzySearcher(labels),
+		StartInSearchMode: true,
+	}
+	i, _, err := prompt.Run()
+	if err != nil {
+		return "", err
+	}
+	return candidates[i].Namespace, nil
+}
+
+// isInteractive returns true when rdu can prompt the user
+func isInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// deploymentCandidate is a deployment namespace and its pull request
+type deploymentCandidate struct {
+	Namespace  string
+	Repository string
+	PR         int
+	State      string
+}
+
+func (c deploymentCandidate) String() string {
+	return fmt.Sprintf("%-24s %s#%d (%s)", c.Namespace, c.Repository, c.PR, c.State)
+}
+
+func listDeploymentCandidates(ctx context.Context) (candidates []deploymentCandidate, err error) {
+	clients, err := k8s.GetClientset()
+	if err != nil {
+		return nil, err
+	}
+	namespaceList, err := k8s.ListNamespaces(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, namespace := range namespaceList.Items {
+		repo, pr := github.MatchDeploymentNamespace(namespace.Name)
+		if repo != "" {
+			candidates = append(candidates, deploymentCandidate{Namespace: namespace.Name, Repository: repo, PR: pr, State: "UNKNOWN"})
+		}
+	}
+
+	// The pull request states are a nice to have, the candidates are listed without them if GitHub is not available
+	cli, err := github.NewGitHubClient(ctx)
+	if err != nil {
+		return candidates, nil
+	}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, pullRequestStateConcurrency)
+	for i := range candidates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			state, err := cli.GetPullRequestState(ctx, candidates[i].Repository, candidates[i].PR)
+			if err == nil {
+				candidates[i].State = state
+			}
+		}()
+	}
+	wg.Wait()
+	return candidates, nil
+}
+
+// newFuzzySearcher returns a promptui searcher which matches the input as a fuzzy, case-insensitive pattern
+func newFuzzySearcher(labels []string) func(input string, index int) bool {
+	return func(input string, index int) bool {
+		return fuzzy.MatchNormalizedFold(strings.TrimSpace(input), labels[index])
+	}
+}
@@ -0,0 +1,22 @@
+package namespace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzySearcher(t *testing.T) {
+	t.Parallel()
+
+	labels := []string{
+		deploymentCandidate{Namespace: "renku-ci-ds-123", Repository: "SwissDataScienceCenter/renku-data-services", PR: 123, State: "OPEN"}.String(),
+		deploymentCandidate{Namespace: "renku-ci-ui-45", Repository: "SwissDataScienceCenter/renku-ui", PR: 45, State: "MERGED"}.String(),
+	}
+	searcher := newFuzzySearcher(labels)
+
+	assert.True(t, searcher("ds123", 0))
+	assert.False(t, searcher("ds123", 1))
+	assert.True(t, searcher("UI merged", 1))
+	assert.True(t, searcher("", 0))
+}
@@ -0,0 +1,159 @@
+package namespace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/github"
+	"github.com/SwissDataScienceCenter/renku-dev-utils/pkg/k8s"
+)
+
+// The environment variable which sets the namespace
+const NamespaceEnvVar string = "RDU_NAMESPACE"
+
+// Source resolves a namespace from one place, e.g. a flag or the current pull request.
+// Resolve returns "" without an error when the source does not apply.
+type Source interface {
+	Name() string
+	Resolve(ctx context.Context) (namespace string, err error)
+}
+
+// Resolver tries its sources in order and returns the first namespace found
+type Resolver struct {
+	sources []Source
+}
+
+func NewResolver(sources ...Source) *Resolver {
+	return &Resolver{sources: sources}
+}
+
+// Resolve returns the first namespace found and the name of the source it came from
+func (r *Resolver) Resolve(ctx context.Context) (namespace string, source string, err error) {
+	failures := []string{}
+	for _, s := range r.sources {
+		namespace, err := s.Resolve(ctx)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", s.Name(), err.Error()))
+			continue
+		}
+		if namespace != "" {
+			return namespace, s.Name(), nil
+		}
+	}
+	if len(failures) > 0 {
+		return "", "", fmt.Errorf("could not find the namespace of the deployment:\n  %s", strings.Join(failures, "\n  "))
+	}
+	return "", "", fmt.Errorf("could not find the namespace of the deployment, pass it with --namespace")
+}
+
+// DefaultSources returns the default resolution chain:
+// flag, environment variable, current deployment, GitHub pull request, kube context
+// and, in a terminal, interactive selection
+func DefaultSources(flagValue string, currentDeploymentPath string) []Source {
+	return []Source{
+		ValueSource{SourceName: "--namespace flag", Value: flagValue},
+		ValueSource{SourceName: fmt.Sprintf("%s environment variable", NamespaceEnvVar), Value: os.Getenv(NamespaceEnvVar)},
+		CurrentDeploymentSource{Path: currentDeploymentPath},
+		GitHubSource{},
+		KubeContextSource{},
+		PickerSource{},
+	}
+}
+
+// ValueSource resolves to a fixed value, e.g. a flag
+type ValueSource struct {
+	SourceName string
+	Value      string
+}
+
+func (s ValueSource) Name() string {
+	return s.SourceName
+}
+
+func (s ValueSource) Resolve(ctx context.Context) (namespace string, err error) {
+	return s.Value, nil
+}
+
+// CurrentDeploymentSource resolves to the deployment selected with "rdu use"
+type CurrentDeploymentSource struct {
+	Path string
+}
+
+func (s CurrentDeploymentSource) Name() string {
+	return "current deployment (rdu use)"
+}
+
+func (s CurrentDeploymentSource) Resolve(ctx context.Context) (namespace string, err error) {
+	return GetCurrentDeployment(s.Path)
+}
+
+// GitHubSource resolves to the deployment of the pull request of the current branch
+type GitHubSource struct{}
+
+func (s GitHubSource) Name() string {
+	return "GitHub pull request"
+}
+
+func (s GitHubSource) Resolve(ctx context.Context) (namespace string, err error) {
+	cli, err := github.NewGitHubClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	return FindCurrentNamespace(ctx, cli)
+}
+
+// KubeContextSource resolves to the namespace of the current kube context.
+// Only deployment namespaces are accepted, so that commands never act on a generic namespace like "default".
+type KubeContextSource struct{}
+
+func (s KubeContextSource) Name() string {
+	return "kube context"
+}
+
+func (s KubeContextSource) Resolve(ctx context.Context) (namespace string, err error) {
+	namespace, err = k8s.GetCurrentContextNamespace()
+	if err != nil || namespace == "" {
+		return namespace, err
+	}
+	if _, pr := github.MatchDeploymentNamespace(namespace); pr == 0 {
+		return "", nil
+	}
+	return namespace, nil
+}
+
+// GetCurrentDeployment returns the namespace saved with SetCurrentDeployment, or "" if there is none
+func GetCurrentDeployment(path string) (namespace string, err error) {
+	if path == "" {
+		return "", nil
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// SetCurrentDeployment saves the namespace of the current deployment, or removes it if namespace is ""
+func SetCurrentDeployment(path string, namespace string) error {
+	if path == "" {
+		return fmt.Errorf("could not determine where to save the current deployment")
+	}
+	if namespace == "" {
+		err := os.Remove(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(namespace+"\n"), 0o644)
+}
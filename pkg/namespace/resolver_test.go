@@ -0,0 +1,89 @@
+package namespace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingSource struct{}
+
+func (s failingSource) Name() string {
+	return "failing"
+}
+
+func (s failingSource) Resolve(ctx context.Context) (namespace string, err error) {
+	return "", errors.New("gh not found")
+}
+
+func TestResolver(t *testing.T) {
+	t.Parallel()
+
+	resolver := NewResolver(
+		ValueSource{SourceName: "flag", Value: ""},
+		failingSource{},
+		ValueSource{SourceName: "env", Value: "renku-ci-ds-1"},
+		ValueSource{SourceName: "other", Value: "renku-ci-ui-2"},
+	)
+	namespace, source, err := resolver.Resolve(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "renku-ci-ds-1", namespace)
+	assert.Equal(t, "env", source)
+
+	_, _, err = NewResolver(ValueSource{SourceName: "flag"}, failingSource{}).Resolve(t.Context())
+	assert.ErrorContains(t, err, "failing: gh not found")
+}
+
+func TestCurrentDeployment(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "renku-dev-utils", "current-deployment")
+
+	namespace, err := GetCurrentDeployment(path)
+	require.NoError(t, err)
+	assert.Equal(t, "", namespace)
+
+	require.NoError(t, SetCurrentDeployment(path, "renku-ci-ds-1"))
+	namespace, err = CurrentDeploymentSource{Path: path}.Resolve(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "renku-ci-ds-1", namespace)
+
+	require.NoError(t, SetCurrentDeployment(path, ""))
+	namespace, err = GetCurrentDeployment(path)
+	require.NoError(t, err)
+	assert.Equal(t, "", namespace)
+}
+
+func TestKubeContextSource(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeKubeconfig := func(namespace string) {
+		kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: dev
+contexts:
+- name: dev
+  context:
+    cluster: dev
+    namespace: %s
+`, namespace)
+		require.NoError(t, os.MkdirAll(filepath.Join(home, ".kube"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(home, ".kube", "config"), []byte(kubeconfig), 0o600))
+	}
+
+	writeKubeconfig("default")
+	namespace, err := KubeContextSource{}.Resolve(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "", namespace)
+
+	writeKubeconfig("renku-ci-ds-12")
+	namespace, err = KubeContextSource{}.Resolve(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "renku-ci-ds-12", namespace)
+}
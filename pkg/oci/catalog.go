@@ -0,0 +1,70 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Matches the next page in a Link header, e.g. `</v2/_catalog?last=b&n=100>; rel="next"`
+var nextLinkRegex = regexp.MustCompile(`<([^>]+)>;\s*rel="?next"?`)
+
+// ListRepositories lists the repositories of a registry which start with prefix, using the catalog API
+func (rc *RegistryClient) ListRepositories(ctx context.Context, domain string, prefix string) (repositories []string, err error) {
+	catalogURL, err := url.Parse(fmt.Sprintf("https://%s/v2/_catalog?n=1000", getRegistryHost(domain)))
+	if err != nil {
+		return nil, err
+	}
+
+	for catalogURL != nil {
+		page, next, err := rc.getCatalogPage(ctx, catalogURL)
+		if err != nil {
+			return nil, err
+		}
+		for _, repository := range page {
+			if strings.HasPrefix(repository, prefix) {
+				repositories = append(repositories, repository)
+			}
+		}
+		catalogURL = next
+	}
+	return repositories, nil
+}
+
+func (rc *RegistryClient) getCatalogPage(ctx context.Context, catalogURL *url.URL) (repositories []string, next *url.URL, err error) {
+	res, err := rc.do(ctx, "GET", catalogURL, []string{"application/json"})
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			fmt.Printf("Warning, could not close response body: %s", err.Error())
+		}
+	}()
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("could not list the repositories of %s: %s", catalogURL.Host, res.Status)
+	}
+
+	var catalog catalogResponse
+	err = json.NewDecoder(res.Body).Decode(&catalog)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	match := nextLinkRegex.FindStringSubmatch(res.Header.Get("Link"))
+	if match != nil {
+		next, err = catalogURL.Parse(match[1])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return catalog.Repositories, next, nil
+}
+
+type catalogResponse struct {
+	Repositories []string `json:"repositories"`
+}
@@ -0,0 +1,34 @@
+package oci
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRepositories(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/_catalog", r.URL.Path)
+		res := catalogResponse{Repositories: []string{"renku/py-basic-jupyterlab", "renku/renku-ui"}}
+		if r.URL.Query().Get("last") == "" {
+			w.Header().Set("Link", `</v2/_catalog?last=renku%2Frenku-ui&n=1000>; rel="next"`)
+		} else {
+			res = catalogResponse{Repositories: []string{"renku/py-datascience-vscodium"}}
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	defer srv.Close()
+
+	serverURL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	rc := &RegistryClient{auth: map[string]string{}, client: srv.Client()}
+
+	repositories, err := rc.ListRepositories(t.Context(), serverURL.Host, "renku/py-")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"renku/py-basic-jupyterlab", "renku/py-datascience-vscodium"}, repositories)
+}
@@ -36,15 +36,38 @@ func (rc *RegistryClient) CheckImage(ctx context.Context, named reference.Named)
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequestWithContext(ctx, "HEAD", manifestURL.String(), nil)
+	res, err = rc.do(ctx, "HEAD", manifestURL, manifestMediaTypes)
+	if err != nil {
+		return res, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return res, fmt.Errorf("image %s does not exist: %s", named.String(), res.Status)
+	}
+
+	contentType := strings.ToLower(res.Header.Get("Content-Type"))
+	if contentType != ociSpec.MediaTypeImageIndex && contentType != ociSpec.MediaTypeImageManifest && contentType != dockerListSpec.MediaTypeManifestList && contentType != dockerSpec.MediaTypeManifest {
+		return res, fmt.Errorf("unexpected response content type %s for image %s", contentType, named.String())
+	}
+
+	return res, nil
+}
+
+// The media types accepted when querying image manifests
+var manifestMediaTypes = []string{
+	ociSpec.MediaTypeImageIndex,
+	ociSpec.MediaTypeImageManifest,
+	dockerListSpec.MediaTypeManifestList,
+	dockerSpec.MediaTypeManifest,
+}
+
+// do sends a request to a registry, authenticating with the challenge of the registry if required
+func (rc *RegistryClient) do(ctx context.Context, method string, requestURL *url.URL, accept []string) (res *http.Response, err error) {
+	req, err := newRegistryRequest(ctx, method, requestURL, accept)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("Accept", ociSpec.MediaTypeImageIndex)
-	req.Header.Add("Accept", ociSpec.MediaTypeImageManifest)
-	req.Header.Add("Accept", dockerListSpec.MediaTypeManifestList)
-	req.Header.Add("Accept", dockerSpec.MediaTypeManifest)
-	authHeader, authFound := rc.auth[manifestURL.Host]
+	authHeader, authFound := rc.auth[requestURL.Host]
 	if authFound {
 		req.Header.Add("Authorization", authHeader)
 	}
@@ -55,11 +78,14 @@ func (rc *RegistryClient) CheckImage(ctx context.Context, named reference.Named)
 
 	// Check if authentication is required
 	if res.StatusCode == http.StatusUnauthorized {
+		if err := res.Body.Close(); err != nil {
+			return nil, err
+		}
 		challenges := auth.ParseAuthHeader(res.Header)
 		var challenge *auth.Challenge = nil
 		token := ""
 		for i := range challenges {
-			to, err := auth.GenerateTokenOptions(ctx, manifestURL.Host, "", "", challenges[i])
+			to, err := auth.GenerateTokenOptions(ctx, requestURL.Host, "", "", challenges[i])
 			if err != nil {
 				log.Printf("could not generate token options from challenge: %+v\n", challenges[i])
 				continue
@@ -74,16 +100,12 @@ func (rc *RegistryClient) CheckImage(ctx context.Context, named reference.Named)
 			break
 		}
 		if challenge == nil {
-			return nil, fmt.Errorf("could not authenticate with registry at %s", manifestURL.Host)
+			return nil, fmt.Errorf("could not authenticate with registry at %s", requestURL.Host)
 		}
-		req, err := http.NewRequestWithContext(ctx, "HEAD", manifestURL.String(), nil)
+		req, err := newRegistryRequest(ctx, method, requestURL, accept)
 		if err != nil {
 			return nil, err
 		}
-		req.Header.Add("Accept", ociSpec.MediaTypeImageIndex)
-		req.Header.Add("Accept", ociSpec.MediaTypeImageManifest)
-		req.Header.Add("Accept", dockerListSpec.MediaTypeManifestList)
-		req.Header.Add("Accept", dockerSpec.MediaTypeManifest)
 		scheme := "Bearer"
 		switch challenge.Scheme {
 		case auth.BasicAuth:
@@ -92,31 +114,30 @@ func (rc *RegistryClient) CheckImage(ctx context.Context, named reference.Named)
 			scheme = "Digest"
 		}
 		// Save the Authorization header for later requests
-		rc.auth[manifestURL.Host] = fmt.Sprintf("%s %s", scheme, token)
-		req.Header.Add("Authorization", rc.auth[manifestURL.Host])
+		rc.auth[requestURL.Host] = fmt.Sprintf("%s %s", scheme, token)
+		req.Header.Add("Authorization", rc.auth[requestURL.Host])
 		res, err = rc.client.Do(req)
 		if err != nil {
 			return res, err
 		}
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return res, fmt.Errorf("image %s does not exist: %s", named.String(), res.Status)
-	}
+	return res, nil
+}
 
-	contentType := strings.ToLower(res.Header.Get("Content-Type"))
-	if contentType != ociSpec.MediaTypeImageIndex && contentType != ociSpec.MediaTypeImageManifest && contentType != dockerListSpec.MediaTypeManifestList && contentType != dockerSpec.MediaTypeManifest {
-		return res, fmt.Errorf("unexpected response content type %s for image %s", contentType, named.String())
+func newRegistryRequest(ctx context.Context, method string, requestURL *url.URL, accept []string) (req *http.Request, err error) {
+	req, err = http.NewRequestWithContext(ctx, method, requestURL.String(), nil)
+	if err != nil {
+		return nil, err
 	}
-
-	return res, nil
+	for _, mediaType := range accept {
+		req.Header.Add("Accept", mediaType)
+	}
+	return req, nil
 }
 
 func GetManifestURLForImage(named reference.Named) (url *url.URL, err error) {
-	domain := reference.Domain(named)
-	if domain == "docker.io" || strings.HasSuffix(domain, ".docker.io") {
-		domain = "registry-1.docker.io"
-	}
+	domain := getRegistryHost(reference.Domain(named))
 	path := reference.Path(named)
 	ref := ""
 	if digested, ok := named.(reference.Digested); ok {
@@ -130,3 +151,11 @@ func GetManifestURLForImage(named reference.Named) (url *url.URL, err error) {
 	manifestURLStr := fmt.Sprintf("https://%s/v2/%s/manifests/%s", domain, path, ref)
 	return url.Parse(manifestURLStr)
 }
+
+// getRegistryHost returns the host serving the registry API of a domain
+func getRegistryHost(domain string) string {
+	if domain == "docker.io" || strings.HasSuffix(domain, ".docker.io") {
+		return "registry-1.docker.io"
+	}
+	return domain
+}
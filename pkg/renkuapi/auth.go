@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -170,7 +171,11 @@ func (auth *RenkuApiAuth) saveRefreshTokenToKeyring() (err error) {
 		return fmt.Errorf("refresh_token is not set")
 	}
 	kUser := fmt.Sprintf("%s:%s", auth.getKeyringUserPrefix(), "refresh_token")
-	return keyring.Set(keyringService, kUser, auth.refreshToken)
+	err = keyring.Set(keyringService, kUser, auth.refreshToken)
+	if err != nil {
+		return err
+	}
+	return auth.addInstance()
 }
 
 func (auth *RenkuApiAuth) deleteRefreshTokenFromKeyring() (err error) {
@@ -185,7 +190,8 @@ func (auth *RenkuApiAuth) getKeyringUserPrefix() string {
 func (auth *RenkuApiAuth) Login(ctx context.Context) error {
 	token, _ := auth.GetAccessToken(ctx)
 	if token != "" {
-		return nil
+		// Tokens saved before the instances were recorded are listed from now on
+		return auth.addInstance()
 	}
 	err := auth.performLogin(ctx)
 	if err != nil {
@@ -500,6 +506,17 @@ func (auth *RenkuApiAuth) postRefeshToken(ctx context.Context, refreshToken stri
 func (auth *RenkuApiAuth) Logout(ctx context.Context) error {
 	err1 := auth.deleteAccessTokenFromKeyring()
 	err2 := auth.deleteRefreshTokenFromKeyring()
+	err := auth.removeInstance()
+	if err != nil {
+		return err
+	}
+	// Logins can have a single token, e.g. when saved by older versions of rdu
+	if err1 == nil && errors.Is(err2, keyring.ErrNotFound) {
+		err2 = nil
+	}
+	if err2 == nil && errors.Is(err1, keyring.ErrNotFound) {
+		err1 = nil
+	}
 	if err1 != nil && err2 != nil {
 		return fmt.Errorf("got errors: %w and %w", err1, err2)
 	}
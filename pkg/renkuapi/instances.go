@@ -0,0 +1,212 @@
+package renkuapi
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/zalando/go-keyring"
+)
+
+// The keyring does not list its entries, the instances with stored tokens are kept in an entry of their own
+const keyringInstancesUser string = "rdu:instances"
+
+// InstanceStatus describes the tokens stored for a renku instance
+type InstanceStatus struct {
+	URL                   string     `json:"url"`
+	User                  string     `json:"user,omitempty"`
+	HasAccessToken        bool       `json:"hasAccessToken"`
+	AccessTokenExpiresAt  *time.Time `json:"accessTokenExpiresAt,omitempty"`
+	HasRefreshToken       bool       `json:"hasRefreshToken"`
+	RefreshTokenExpiresAt *time.Time `json:"refreshTokenExpiresAt,omitempty"`
+}
+
+// tokenClaims holds the claims of a Keycloak token which identify the user
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	PreferredUsername string `json:"preferred_username"`
+	Email             string `json:"email"`
+}
+
+// ListInstances returns the base URLs of the renku instances with stored tokens
+func ListInstances() (instances []string, err error) {
+	content, err := keyring.Get(keyringService, keyringInstancesUser)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return []string{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal([]byte(content), &instances)
+	if err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// FindInstances returns the base URLs of the renku instances with stored tokens, like ListInstances.
+// The keyring is also searched for tokens of the candidate base URLs which were stored before the instances were recorded.
+func FindInstances(candidates []string) (instances []string, err error) {
+	instances, err = ListInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		auth, err := NewRenkuApiAuth(candidate)
+		if err != nil {
+			return nil, err
+		}
+		baseURL := auth.baseURL.String()
+		if slices.Contains(instances, baseURL) {
+			continue
+		}
+		hasTokens, err := auth.hasTokensInKeyring()
+		if err != nil {
+			return nil, err
+		}
+		if hasTokens {
+			instances = append(instances, baseURL)
+		}
+	}
+	slices.Sort(instances)
+	return instances, nil
+}
+
+// RecordInstances adds instances, e.g. found with FindInstances, to the recorded ones
+func RecordInstances(instances []string) error {
+	recorded, err := ListInstances()
+	if err != nil {
+		return err
+	}
+	added := false
+	for _, instance := range instances {
+		if !slices.Contains(recorded, instance) {
+			recorded = append(recorded, instance)
+			added = true
+		}
+	}
+	if !added {
+		return nil
+	}
+	slices.Sort(recorded)
+	return saveInstances(recorded)
+}
+
+func (auth *RenkuApiAuth) hasTokensInKeyring() (hasTokens bool, err error) {
+	_, err = auth.getAccessTokenFromKeyring()
+	if err == nil {
+		return true, nil
+	} else if !errors.Is(err, keyring.ErrNotFound) {
+		return false, err
+	}
+	_, err = auth.getRefreshTokenFromKeyring()
+	if err == nil {
+		return true, nil
+	} else if !errors.Is(err, keyring.ErrNotFound) {
+		return false, err
+	}
+	return false, nil
+}
+
+func saveInstances(instances []string) error {
+	if len(instances) == 0 {
+		err := keyring.Delete(keyringService, keyringInstancesUser)
+		if errors.Is(err, keyring.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	content, err := json.Marshal(instances)
+	if err != nil {
+		return err
+	}
+	return keyring.Set(keyringService, keyringInstancesUser, string(content))
+}
+
+func (auth *RenkuApiAuth) addInstance() error {
+	instances, err := ListInstances()
+	if err != nil {
+		return err
+	}
+	baseURL := auth.baseURL.String()
+	if slices.Contains(instances, baseURL) {
+		return nil
+	}
+	instances = append(instances, baseURL)
+	slices.Sort(instances)
+	return saveInstances(instances)
+}
+
+func (auth *RenkuApiAuth) removeInstance() error {
+	instances, err := ListInstances()
+	if err != nil {
+		return err
+	}
+	baseURL := auth.baseURL.String()
+	if !slices.Contains(instances, baseURL) {
+		return nil
+	}
+	return saveInstances(slices.DeleteFunc(instances, func(instance string) bool { return instance == baseURL }))
+}
+
+// Status returns the user and the expiry of the tokens stored for the instance, without refreshing them
+func (auth *RenkuApiAuth) Status() (status InstanceStatus, err error) {
+	status.URL = auth.baseURL.String()
+
+	accessToken, err := auth.getAccessTokenFromKeyring()
+	if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return status, err
+	}
+	if accessToken != "" {
+		status.HasAccessToken = true
+		claims := parseTokenClaims(accessToken)
+		status.User = claims.getUser()
+		status.AccessTokenExpiresAt = claims.getExpirationTime()
+	}
+
+	refreshToken, err := auth.getRefreshTokenFromKeyring()
+	if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return status, err
+	}
+	if refreshToken != "" {
+		status.HasRefreshToken = true
+		claims := parseTokenClaims(refreshToken)
+		if status.User == "" {
+			status.User = claims.getUser()
+		}
+		status.RefreshTokenExpiresAt = claims.getExpirationTime()
+	}
+
+	return status, nil
+}
+
+// parseTokenClaims reads the claims of a token without verifying it, invalid tokens give empty claims
+func parseTokenClaims(token string) (claims tokenClaims) {
+	parser := jwt.NewParser()
+	_, _, err := parser.ParseUnverified(token, &claims)
+	if err != nil {
+		return tokenClaims{}
+	}
+	return claims
+}
+
+func (claims tokenClaims) getUser() string {
+	if claims.PreferredUsername != "" {
+		return claims.PreferredUsername
+	}
+	if claims.Email != "" {
+		return claims.Email
+	}
+	return claims.Subject
+}
+
+// getExpirationTime returns nil for tokens which do not expire, like offline tokens
+func (claims tokenClaims) getExpirationTime() *time.Time {
+	if claims.ExpiresAt == nil || claims.ExpiresAt.IsZero() {
+		return nil
+	}
+	return &claims.ExpiresAt.Time
+}
@@ -0,0 +1,90 @@
+package renkuapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+func newTestToken(t *testing.T, username string, expiresAt time.Time) string {
+	t.Helper()
+
+	claims := tokenClaims{PreferredUsername: username}
+	if !expiresAt.IsZero() {
+		claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test"))
+	require.NoError(t, err)
+	return token
+}
+
+func TestInstances(t *testing.T) {
+	keyring.MockInit()
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	for _, baseURL := range []string{"https://renku-ci-ui-1.dev.renku.ch", "https://renku-ci-ds-2.dev.renku.ch"} {
+		auth, err := NewRenkuApiAuth(baseURL)
+		require.NoError(t, err)
+		auth.accessToken = newTestToken(t, "alice", expiresAt)
+		auth.refreshToken = newTestToken(t, "alice", time.Time{})
+		require.NoError(t, auth.saveAccessTokenToKeyring())
+		require.NoError(t, auth.saveRefreshTokenToKeyring())
+	}
+
+	instances, err := ListInstances()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://renku-ci-ds-2.dev.renku.ch", "https://renku-ci-ui-1.dev.renku.ch"}, instances)
+
+	auth, err := NewRenkuApiAuth("https://renku-ci-ui-1.dev.renku.ch")
+	require.NoError(t, err)
+	status, err := auth.Status()
+	require.NoError(t, err)
+	assert.Equal(t, "alice", status.User)
+	assert.True(t, status.HasAccessToken)
+	require.NotNil(t, status.AccessTokenExpiresAt)
+	assert.True(t, expiresAt.Equal(*status.AccessTokenExpiresAt))
+	assert.True(t, status.HasRefreshToken)
+	assert.Nil(t, status.RefreshTokenExpiresAt)
+
+	require.NoError(t, auth.Logout(t.Context()))
+	instances, err = ListInstances()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://renku-ci-ds-2.dev.renku.ch"}, instances)
+}
+
+func TestFindInstances(t *testing.T) {
+	keyring.MockInit()
+
+	// Tokens saved before the instances were recorded
+	token := newTestToken(t, "alice", time.Time{})
+	require.NoError(t, keyring.Set(keyringService, "rdu:https://renku-ci-ui-3.dev.renku.ch:refresh_token", token))
+
+	instances, err := ListInstances()
+	require.NoError(t, err)
+	assert.Empty(t, instances)
+
+	instances, err = FindInstances([]string{"https://renku-ci-ui-3.dev.renku.ch", "https://renku-ci-ui-4.dev.renku.ch"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://renku-ci-ui-3.dev.renku.ch"}, instances)
+
+	// Finding instances does not record them
+	recorded, err := ListInstances()
+	require.NoError(t, err)
+	assert.Empty(t, recorded)
+
+	require.NoError(t, RecordInstances(instances))
+	recorded, err = ListInstances()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://renku-ci-ui-3.dev.renku.ch"}, recorded)
+
+	auth, err := NewRenkuApiAuth("https://renku-ci-ui-3.dev.renku.ch")
+	require.NoError(t, err)
+	require.NoError(t, auth.Logout(t.Context()))
+	instances, err = FindInstances([]string{"https://renku-ci-ui-3.dev.renku.ch"})
+	require.NoError(t, err)
+	assert.Empty(t, instances)
+}